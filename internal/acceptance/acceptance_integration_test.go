@@ -297,7 +297,7 @@ func TestListTablesMissingSchema(t *testing.T) {
 func TestPreviewTable(t *testing.T) {
 	for _, f := range fixtures {
 		t.Run(f.name, func(t *testing.T) {
-			preview, err := f.ds.PreviewTable(f.schema, f.table)
+			preview, err := f.ds.PreviewTable(f.schema, f.table, internal.Preview{})
 			require.NoError(t, err)
 			require.NotEmpty(t, preview, "preview must include at least the header row")
 
@@ -323,6 +323,23 @@ func TestDescribeTable(t *testing.T) {
 	}
 }
 
+func TestListColumns(t *testing.T) {
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			columns, err := f.ds.ListColumns(f.schema, f.table)
+			require.NoError(t, err)
+			require.NotEmpty(t, columns)
+
+			preview, err := f.ds.PreviewTable(f.schema, f.table, internal.Preview{})
+			require.NoError(t, err)
+			require.Len(t, columns, len(preview[0]), "columns must match the preview header")
+			for i, c := range columns {
+				assert.Equal(t, *preview[0][i], c.Name)
+			}
+		})
+	}
+}
+
 func TestQuery(t *testing.T) {
 	for _, f := range fixtures {
 		t.Run(f.name, func(t *testing.T) {
@@ -0,0 +1,277 @@
+// Package changeset collects changes to the rows of a table until they are
+// reviewed and applied together, in a single transaction.
+package changeset
+
+import (
+	"slices"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/sqlgen"
+)
+
+// State tells how a row is changed.
+type State int
+
+const (
+	// Unchanged rows are as read from the table.
+	Unchanged State = iota
+	// Updated rows have cells with new values.
+	Updated
+	// Inserted rows are new.
+	Inserted
+	// Deleted rows are to be removed.
+	Deleted
+)
+
+type (
+	// Changeset tracks the changes made to the rows of a table result. Like every result, the data the changes
+	// apply to holds the column names in its first row. Row indexes stay valid while changes are pending:
+	// deleted rows are only flagged, and inserted rows are appended.
+	Changeset struct {
+		// keys are the indexes of the primary key columns.
+		keys []int
+		// original holds the values updated cells had before, by row and column.
+		original map[int]map[int]*string
+		deleted  map[int]bool
+		// inserted holds the columns every inserted row sets. The other columns get their default values.
+		inserted map[int][]bool
+	}
+
+	// RowChange describes the change of a row for reviewing it.
+	RowChange struct {
+		Row   int
+		State State
+		// Cells are the changed cells of updated rows, the set cells of inserted rows and every cell of deleted rows.
+		Cells []CellChange
+	}
+
+	// CellChange is the value of a cell before and after the change. Before is unset for inserted rows,
+	// After for deleted ones.
+	CellChange struct {
+		Column        string
+		Before, After *string
+	}
+)
+
+// New returns an empty changeset for a table with the primary key columns at the indexes.
+func New(keys []int) *Changeset {
+	return &Changeset{keys: keys, original: map[int]map[int]*string{}, deleted: map[int]bool{}, inserted: map[int][]bool{}}
+}
+
+// Len returns the number of changed rows.
+func (c *Changeset) Len() int {
+	n := len(c.deleted) + len(c.inserted)
+	for row := range c.original {
+		if !c.deleted[row] {
+			n++
+		}
+	}
+	return n
+}
+
+// State returns how the row is changed.
+func (c *Changeset) State(row int) State {
+	switch {
+	case c.deleted[row]:
+		return Deleted
+	case c.inserted[row] != nil:
+		return Inserted
+	case c.original[row] != nil:
+		return Updated
+	default:
+		return Unchanged
+	}
+}
+
+// Changed reports whether the cell has a new value: it was updated, or it is set by an inserted row.
+func (c *Changeset) Changed(row, column int) bool {
+	if set := c.inserted[row]; set != nil {
+		return set[column]
+	}
+	_, ok := c.original[row][column]
+	return ok
+}
+
+// Update sets the cell to the value. Setting a cell back to its original value drops the change.
+func (c *Changeset) Update(data [][]*string, row, column int, value *string) {
+	if set := c.inserted[row]; set != nil {
+		set[column] = true
+		data[row][column] = value
+		return
+	}
+
+	if _, ok := c.original[row][column]; !ok {
+		if c.original[row] == nil {
+			c.original[row] = map[int]*string{}
+		}
+		c.original[row][column] = data[row][column]
+	}
+	data[row][column] = value
+
+	if original := c.original[row][column]; equal(original, value) {
+		delete(c.original[row], column)
+		if len(c.original[row]) == 0 {
+			delete(c.original, row)
+		}
+	}
+}
+
+// Insert appends a row with the values. Only the columns which are set are inserted; the others
+// get their default values.
+func (c *Changeset) Insert(data [][]*string, values []*string, set []bool) [][]*string {
+	c.inserted[len(data)] = set
+	return append(data, values)
+}
+
+// ToggleDelete flags the row for deletion, or keeps it again. Inserted rows are dropped right away.
+func (c *Changeset) ToggleDelete(data [][]*string, row int) [][]*string {
+	switch {
+	case c.inserted[row] != nil:
+		// Inserted rows are the last ones, so only inserted rows move up.
+		for r := row + 1; r < len(data); r++ {
+			c.inserted[r-1] = c.inserted[r]
+		}
+		delete(c.inserted, len(data)-1)
+		return slices.Delete(data, row, row+1)
+	case c.deleted[row]:
+		delete(c.deleted, row)
+	default:
+		c.deleted[row] = true
+	}
+
+	return data
+}
+
+// Revert restores the original values, drops the inserted rows and forgets every change.
+func (c *Changeset) Revert(data [][]*string) [][]*string {
+	for row, columns := range c.original {
+		for column, value := range columns {
+			data[row][column] = value
+		}
+	}
+
+	end := len(data)
+	for row := range c.inserted {
+		end = min(end, row)
+	}
+	*c = *New(c.keys)
+
+	return data[:end]
+}
+
+// Key returns the names and the original values of the primary key columns of the row.
+func (c *Changeset) Key(data [][]*string, row int) ([]string, []*string) {
+	names := make([]string, len(c.keys))
+	values := make([]*string, len(c.keys))
+	for i, k := range c.keys {
+		names[i], values[i] = column(data, k), data[row][k]
+		if original, ok := c.original[row][k]; ok {
+			values[i] = original
+		}
+	}
+
+	return names, values
+}
+
+// Rows returns the changed rows in the order their statements run: deletions first, so that inserted rows may reuse
+// their keys, then updates and insertions.
+func (c *Changeset) Rows() []int {
+	var deleted, updated, inserted []int
+	for row := range c.deleted {
+		deleted = append(deleted, row)
+	}
+	for row := range c.original {
+		if !c.deleted[row] {
+			updated = append(updated, row)
+		}
+	}
+	for row := range c.inserted {
+		inserted = append(inserted, row)
+	}
+	for _, rows := range [][]int{deleted, updated, inserted} {
+		slices.Sort(rows)
+	}
+
+	return slices.Concat(deleted, updated, inserted)
+}
+
+// Statements returns the statements applying the changes to the table, quoted for the dialect.
+func (c *Changeset) Statements(d sqlgen.Dialect, table string, data [][]*string) []internal.Statement {
+	var statements []internal.Statement
+	for _, row := range c.Rows() {
+		var columns []string
+		var values []*string
+		for _, cell := range c.changedColumns(row) {
+			columns, values = append(columns, column(data, cell)), append(values, data[row][cell])
+		}
+
+		switch c.State(row) {
+		case Deleted:
+			keys, keyValues := c.Key(data, row)
+			statements = append(statements, d.Delete(table, keys, keyValues))
+		case Updated:
+			keys, keyValues := c.Key(data, row)
+			statements = append(statements, d.Update(table, columns, values, keys, keyValues))
+		case Inserted:
+			statements = append(statements, d.InsertRow(table, columns, values))
+		}
+	}
+
+	return statements
+}
+
+// Diff describes the changed rows, in the order of their statements.
+func (c *Changeset) Diff(data [][]*string) []RowChange {
+	var changes []RowChange
+	for _, row := range c.Rows() {
+		change := RowChange{Row: row, State: c.State(row)}
+		switch change.State {
+		case Deleted:
+			for i, value := range data[row] {
+				change.Cells = append(change.Cells, CellChange{Column: column(data, i), Before: value})
+			}
+		case Updated:
+			for _, i := range c.changedColumns(row) {
+				change.Cells = append(change.Cells, CellChange{Column: column(data, i), Before: c.original[row][i], After: data[row][i]})
+			}
+		case Inserted:
+			for _, i := range c.changedColumns(row) {
+				change.Cells = append(change.Cells, CellChange{Column: column(data, i), After: data[row][i]})
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// changedColumns returns the indexes of the changed cells of the row in column order.
+func (c *Changeset) changedColumns(row int) []int {
+	var columns []int
+	if set := c.inserted[row]; set != nil {
+		for i, ok := range set {
+			if ok {
+				columns = append(columns, i)
+			}
+		}
+		return columns
+	}
+
+	for i := range c.original[row] {
+		columns = append(columns, i)
+	}
+	slices.Sort(columns)
+
+	return columns
+}
+
+func column(data [][]*string, index int) string {
+	if name := data[0][index]; name != nil {
+		return *name
+	}
+	return ""
+}
+
+func equal(a, b *string) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
@@ -0,0 +1,111 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/sqlgen"
+	"github.com/stretchr/testify/assert"
+)
+
+func sptr(s string) *string {
+	return &s
+}
+
+// users returns a result with the primary key in the second column.
+func users() [][]*string {
+	return [][]*string{
+		{sptr("name"), sptr("id")},
+		{sptr("ann"), sptr("1")},
+		{sptr("bob"), sptr("2")},
+		{nil, sptr("3")},
+	}
+}
+
+func TestChangeset_Update(t *testing.T) {
+	data := users()
+	c := New([]int{1})
+
+	c.Update(data, 1, 0, sptr("anne"))
+	c.Update(data, 1, 1, sptr("10"))
+	assert.Equal(t, Updated, c.State(1))
+	assert.True(t, c.Changed(1, 0))
+	assert.Equal(t, 1, c.Len())
+
+	keys, values := c.Key(data, 1)
+	assert.Equal(t, []string{"id"}, keys)
+	assert.Equal(t, []*string{sptr("1")}, values, "rows are identified by their original key")
+
+	c.Update(data, 1, 1, sptr("1"))
+	c.Update(data, 1, 0, sptr("ann"))
+	assert.Equal(t, Unchanged, c.State(1), "restoring the original values drops the change")
+	assert.Zero(t, c.Len())
+
+	c.Update(data, 3, 0, sptr("cid"))
+	c.Update(data, 3, 0, nil)
+	assert.Equal(t, Unchanged, c.State(3))
+}
+
+func TestChangeset_InsertAndDelete(t *testing.T) {
+	data := users()
+	c := New([]int{1})
+
+	data = c.Insert(data, []*string{sptr("dan"), nil}, []bool{true, false})
+	data = c.Insert(data, []*string{sptr("eve"), sptr("9")}, []bool{true, true})
+	assert.Len(t, data, 6)
+	assert.Equal(t, Inserted, c.State(4))
+	assert.False(t, c.Changed(4, 1), "unset columns get their default")
+
+	c.Update(data, 4, 1, sptr("8"))
+	assert.True(t, c.Changed(4, 1))
+
+	data = c.ToggleDelete(data, 4)
+	assert.Len(t, data, 5, "inserted rows are dropped")
+	assert.Equal(t, Inserted, c.State(4))
+	assert.Equal(t, "eve", *data[4][0])
+	assert.Equal(t, Unchanged, c.State(5))
+
+	data = c.ToggleDelete(data, 2)
+	assert.Equal(t, Deleted, c.State(2))
+	data = c.ToggleDelete(data, 2)
+	assert.Equal(t, Unchanged, c.State(2))
+	data = c.ToggleDelete(data, 2)
+	assert.Equal(t, 2, c.Len())
+	assert.Len(t, data, 5)
+}
+
+func TestChangeset_Statements(t *testing.T) {
+	data := users()
+	c := New([]int{1})
+	c.Update(data, 3, 0, sptr("cid"))
+	c.Update(data, 1, 0, sptr("anne"))
+	data = c.ToggleDelete(data, 2)
+	data = c.Insert(data, []*string{sptr("dan"), nil}, []bool{true, false})
+
+	d := sqlgen.DialectFor("postgresql")
+	assert.Equal(t, []internal.Statement{
+		{SQL: `DELETE FROM "users" WHERE "id" = $1`, Args: []any{"2"}},
+		{SQL: `UPDATE "users" SET "name" = $1 WHERE "id" = $2`, Args: []any{"anne", "1"}},
+		{SQL: `UPDATE "users" SET "name" = $1 WHERE "id" = $2`, Args: []any{"cid", "3"}},
+		{SQL: `INSERT INTO "users" ("name") VALUES ($1)`, Args: []any{"dan"}},
+	}, c.Statements(d, `"users"`, data))
+
+	assert.Equal(t, []RowChange{
+		{Row: 2, State: Deleted, Cells: []CellChange{{Column: "name", Before: sptr("bob")}, {Column: "id", Before: sptr("2")}}},
+		{Row: 1, State: Updated, Cells: []CellChange{{Column: "name", Before: sptr("ann"), After: sptr("anne")}}},
+		{Row: 3, State: Updated, Cells: []CellChange{{Column: "name", After: sptr("cid")}}},
+		{Row: 4, State: Inserted, Cells: []CellChange{{Column: "name", After: sptr("dan")}}},
+	}, c.Diff(data))
+}
+
+func TestChangeset_Revert(t *testing.T) {
+	data := users()
+	c := New([]int{1})
+	c.Update(data, 1, 0, sptr("anne"))
+	data = c.ToggleDelete(data, 2)
+	data = c.Insert(data, []*string{sptr("dan"), nil}, []bool{true, false})
+
+	assert.Equal(t, users(), c.Revert(data))
+	assert.Zero(t, c.Len())
+	assert.Equal(t, Unchanged, c.State(2))
+}
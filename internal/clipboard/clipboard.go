@@ -0,0 +1,92 @@
+// Package clipboard copies text to the system clipboard: through the terminal with
+// the OSC 52 escape sequence, which also works over SSH and inside tmux, and with a
+// local clipboard tool when one is installed.
+package clipboard
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Terminal posts data to the clipboard of the terminal emulator, as tcell.Screen does with OSC 52.
+type Terminal interface {
+	SetClipboard(data []byte)
+}
+
+// Tool is a local command reading the text to copy from its standard input.
+type Tool struct {
+	Name string
+	Args []string
+}
+
+// Candidate tools, checked in order by Find.
+var (
+	pbcopy = Tool{Name: "pbcopy"}
+	wlCopy = Tool{Name: "wl-copy"}
+	xclip  = Tool{Name: "xclip", Args: []string{"-selection", "clipboard"}}
+	xsel   = Tool{Name: "xsel", Args: []string{"--clipboard", "--input"}}
+	clip   = Tool{Name: "clip.exe"}
+)
+
+// Overridable for tests.
+var (
+	goos     = runtime.GOOS
+	lookPath = exec.LookPath
+	getenv   = os.Getenv
+)
+
+// Find returns the local clipboard tool of the desktop session, if one is installed.
+// Sessions over SSH have no local clipboard to talk to, so they rely on the terminal.
+func Find() (Tool, bool) {
+	if getenv("SSH_CONNECTION") != "" || getenv("SSH_TTY") != "" {
+		return Tool{}, false
+	}
+
+	var candidates []Tool
+	switch {
+	case goos == "darwin":
+		candidates = []Tool{pbcopy}
+	case goos == "windows":
+		candidates = []Tool{clip}
+	case getenv("WAYLAND_DISPLAY") != "":
+		candidates = []Tool{wlCopy, xclip, xsel}
+	case getenv("DISPLAY") != "":
+		candidates = []Tool{xclip, xsel}
+	default:
+		// Windows Subsystem for Linux reaches the Windows clipboard without a display.
+		candidates = []Tool{clip}
+	}
+
+	for _, t := range candidates {
+		if _, err := lookPath(t.Name); err == nil {
+			return t, true
+		}
+	}
+
+	return Tool{}, false
+}
+
+// Copy runs the tool with the text as its input.
+func (t Tool) Copy(text string) error {
+	cmd := exec.Command(t.Name, t.Args...) // nolint:gosec // the tool is one of the fixed candidates.
+	cmd.Stdin = strings.NewReader(text)
+
+	return cmd.Run()
+}
+
+// Copy puts the text on the clipboard through the terminal, and through the local clipboard tool if there is one.
+// It returns the name of the tool used, or "terminal" when only the terminal was asked to copy.
+func Copy(terminal Terminal, text string) (string, error) {
+	if terminal != nil {
+		terminal.SetClipboard([]byte(text))
+	}
+
+	tool, ok := Find()
+	if !ok {
+		return "terminal", nil
+	}
+
+	return tool.Name, tool.Copy(text)
+}
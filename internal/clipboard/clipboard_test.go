@@ -0,0 +1,68 @@
+package clipboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeEnvironment(t *testing.T, os string, env map[string]string, installed ...string) {
+	oldGOOS, oldLookPath, oldGetenv := goos, lookPath, getenv
+	t.Cleanup(func() { goos, lookPath, getenv = oldGOOS, oldLookPath, oldGetenv })
+
+	goos = os
+	getenv = func(key string) string { return env[key] }
+	lookPath = func(name string) (string, error) {
+		for _, i := range installed {
+			if i == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		env       map[string]string
+		installed []string
+		want      string
+	}{
+		{"macOS", "darwin", nil, []string{"pbcopy"}, "pbcopy"},
+		{"Wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, []string{"xclip", "wl-copy"}, "wl-copy"},
+		{"X11 with xsel only", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xsel", "wl-copy"}, "xsel"},
+		{"WSL", "linux", nil, []string{"clip.exe"}, "clip.exe"},
+		{"nothing installed", "linux", map[string]string{"DISPLAY": ":0"}, nil, ""},
+		{"SSH session", "linux", map[string]string{"DISPLAY": ":0", "SSH_CONNECTION": "10.0.0.1 22 10.0.0.2 22"}, []string{"xclip"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeEnvironment(t, tt.goos, tt.env, tt.installed...)
+			tool, ok := Find()
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, tool.Name)
+		})
+	}
+}
+
+type fakeTerminal struct {
+	data []byte
+}
+
+func (f *fakeTerminal) SetClipboard(data []byte) {
+	f.data = data
+}
+
+func TestCopy_Terminal(t *testing.T) {
+	fakeEnvironment(t, "linux", map[string]string{"SSH_TTY": "/dev/pts/1"})
+
+	terminal := &fakeTerminal{}
+	via, err := Copy(terminal, "select 1")
+	assert.NoError(t, err)
+	assert.Equal(t, "terminal", via)
+	assert.Equal(t, "select 1", string(terminal.data))
+}
@@ -0,0 +1,365 @@
+// Package completion suggests SQL keywords, schemas, tables and columns for
+// the text around a cursor, based on the clause the cursor is in and the
+// tables the statement refers to.
+package completion
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/sqlparse"
+)
+
+// Kind tells what a candidate names.
+type Kind int
+
+const (
+	// Keyword candidates are SQL keywords.
+	Keyword Kind = iota
+	// Schema candidates are schema names.
+	Schema
+	// Table candidates are table names.
+	Table
+	// Column candidates are column names.
+	Column
+)
+
+// String returns the lower case name of the kind.
+func (k Kind) String() string {
+	return [...]string{"keyword", "schema", "table", "column"}[k]
+}
+
+type (
+	// Candidate is a single suggestion.
+	Candidate struct {
+		Text string
+		Kind Kind
+		// Detail is a short description shown next to the text, e.g. the column type.
+		Detail string
+	}
+
+	// Metadata provides the names to complete. A lookup may report false while the names are not available yet.
+	Metadata interface {
+		Schemas() ([]string, bool)
+		Tables(schema string) ([]string, bool)
+		Columns(schema, table string) ([]internal.Column, bool)
+	}
+
+	// tableRef is a table mentioned by the statement, e.g. `employees.salaries AS s`.
+	tableRef struct {
+		schema, table, alias string
+	}
+)
+
+// tableClauses are the keywords followed by a table name.
+var tableClauses = map[string]bool{
+	"FROM": true, "JOIN": true, "INTO": true, "UPDATE": true, "TABLE": true, "DESCRIBE": true, "TRUNCATE": true,
+}
+
+// columnClauses are the keywords followed by expressions over columns.
+var columnClauses = map[string]bool{
+	"SELECT": true, "WHERE": true, "ON": true, "AND": true, "OR": true, "BY": true, "SET": true, "HAVING": true,
+	"WHEN": true, "THEN": true, "ELSE": true, "NOT": true, "DISTINCT": true, "RETURNING": true,
+}
+
+// Complete returns the candidates for the word ending at the byte offset of the text, and the offset where that
+// word starts. The schema is the one unqualified table names belong to.
+func Complete(text string, offset int, schema string, meta Metadata) (int, []Candidate) {
+	tokens := sqlparse.Tokenize(text)
+	for _, t := range tokens {
+		// Strings, comments and quoted names may be unterminated, and then extend up to the cursor.
+		quoted := t.Kind == sqlparse.String || t.Kind == sqlparse.Comment || t.Kind == sqlparse.QuotedIdentifier
+		if quoted && t.Start < offset && (offset < t.End || t.End == len(text) && !closed(t)) {
+			return offset, nil
+		}
+	}
+	statement := statementTokens(tokens, offset)
+
+	// Split the statement into the tokens before the word being typed and the word itself.
+	from, prefix := offset, ""
+	var before []sqlparse.Token
+	for _, t := range statement {
+		if t.End < offset || t.End == offset && !isWord(t) {
+			before = append(before, t)
+			continue
+		}
+		if t.Start < offset && isWord(t) {
+			from, prefix = t.Start, text[t.Start:offset]
+		}
+		break
+	}
+	before = significant(before)
+	refs := tableRefs(statement)
+
+	var candidates []Candidate
+	n := len(before)
+	switch {
+	case n >= 2 && before[n-1].Text == "." && isName(before[n-2]):
+		candidates = qualified(sqlparse.Unquote(before[n-2].Text), schema, refs, meta)
+	case n >= 1 && strings.EqualFold(before[n-1].Text, "USE"):
+		candidates = schemas(meta)
+	case inTableClause(before):
+		candidates = append(tables(schema, meta), schemas(meta)...)
+	case inColumnClause(before):
+		candidates = append(columns(schema, refs, meta), keywords(prefix)...)
+	default:
+		candidates = append(keywords(prefix), tables(schema, meta)...)
+	}
+
+	return from, filter(candidates, prefix)
+}
+
+// statementTokens returns the tokens of the statement the offset belongs to.
+func statementTokens(tokens []sqlparse.Token, offset int) []sqlparse.Token {
+	start, end := 0, len(tokens)
+	for i, t := range tokens {
+		if t.Kind != sqlparse.Punctuation || t.Text != ";" {
+			continue
+		}
+		if t.End <= offset {
+			start = i + 1
+		} else {
+			end = i
+			break
+		}
+	}
+
+	return tokens[start:end]
+}
+
+// closed reports whether a string, comment or quoted name token is terminated.
+func closed(t sqlparse.Token) bool {
+	switch t.Kind {
+	case sqlparse.Comment:
+		return strings.HasPrefix(t.Text, "/*") && len(t.Text) >= 4 && strings.HasSuffix(t.Text, "*/")
+	default:
+		last := t.Text[len(t.Text)-1]
+		return len(t.Text) >= 2 && (last == '\'' || last == '"' || last == '`' || last == '$')
+	}
+}
+
+func significant(tokens []sqlparse.Token) []sqlparse.Token {
+	var result []sqlparse.Token
+	for _, t := range tokens {
+		if t.Significant() {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func isWord(t sqlparse.Token) bool {
+	return t.Kind == sqlparse.Identifier || t.Kind == sqlparse.Keyword
+}
+
+func isName(t sqlparse.Token) bool {
+	return t.Kind == sqlparse.Identifier || t.Kind == sqlparse.QuotedIdentifier
+}
+
+// clause returns the last keyword before the cursor which starts a table or a column clause.
+func clause(before []sqlparse.Token) (string, int) {
+	for i := len(before) - 1; i >= 0; i-- {
+		if before[i].Kind != sqlparse.Keyword {
+			continue
+		}
+		if kw := strings.ToUpper(before[i].Text); tableClauses[kw] || columnClauses[kw] {
+			return kw, i
+		}
+	}
+	return "", -1
+}
+
+// inTableClause reports whether a table name is expected: right after FROM and the like,
+// or after a comma in the table list of a FROM clause.
+func inTableClause(before []sqlparse.Token) bool {
+	if len(before) == 0 {
+		return false
+	}
+	last := before[len(before)-1]
+	if last.Kind == sqlparse.Keyword && tableClauses[strings.ToUpper(last.Text)] {
+		return true
+	}
+	kw, _ := clause(before)
+	return last.Text == "," && kw == "FROM"
+}
+
+func inColumnClause(before []sqlparse.Token) bool {
+	kw, i := clause(before)
+	if columnClauses[kw] {
+		return true
+	}
+	// Past the table name of a table clause, e.g. `UPDATE t SET` is handled above, `INSERT INTO t (` lists columns.
+	return tableClauses[kw] && i < len(before)-1 && before[len(before)-1].Text == "("
+}
+
+// tableRefs collects the tables the statement refers to in FROM, JOIN, UPDATE and INTO clauses.
+func tableRefs(statement []sqlparse.Token) []tableRef {
+	tokens := significant(statement)
+	var refs []tableRef
+	for i := 0; i < len(tokens); i++ {
+		kw := strings.ToUpper(tokens[i].Text)
+		if tokens[i].Kind != sqlparse.Keyword || kw != "FROM" && kw != "JOIN" && kw != "UPDATE" && kw != "INTO" {
+			continue
+		}
+		for {
+			ref, next, ok := parseTableRef(tokens, i+1)
+			if !ok {
+				break
+			}
+			refs = append(refs, ref)
+			i = next - 1
+			// A FROM clause may list several tables.
+			if kw != "FROM" || next >= len(tokens) || tokens[next].Text != "," {
+				break
+			}
+			i = next
+		}
+	}
+
+	return refs
+}
+
+// parseTableRef parses `[schema.]table [[AS] alias]` starting at i and returns the index following it.
+func parseTableRef(tokens []sqlparse.Token, i int) (tableRef, int, bool) {
+	if i >= len(tokens) || !isName(tokens[i]) {
+		return tableRef{}, i, false
+	}
+
+	ref := tableRef{table: sqlparse.Unquote(tokens[i].Text)}
+	i++
+	if i+1 < len(tokens) && tokens[i].Text == "." && isName(tokens[i+1]) {
+		ref.schema, ref.table = ref.table, sqlparse.Unquote(tokens[i+1].Text)
+		i += 2
+	}
+	if i < len(tokens) && strings.EqualFold(tokens[i].Text, "AS") {
+		i++
+	}
+	if i < len(tokens) && isName(tokens[i]) {
+		ref.alias = sqlparse.Unquote(tokens[i].Text)
+		i++
+	}
+
+	return ref, i, true
+}
+
+// qualified completes the word following `qualifier.`: the columns of an aliased or named table,
+// or the tables of a schema.
+func qualified(qualifier, schema string, refs []tableRef, meta Metadata) []Candidate {
+	for _, ref := range refs {
+		if strings.EqualFold(ref.alias, qualifier) || ref.alias == "" && strings.EqualFold(ref.table, qualifier) {
+			return tableColumns(ref.schemaOr(schema), ref.table, meta)
+		}
+	}
+	if names, _ := meta.Schemas(); containsFold(names, qualifier) {
+		return tables(qualifier, meta)
+	}
+	if names, _ := meta.Tables(schema); containsFold(names, qualifier) {
+		return tableColumns(schema, qualifier, meta)
+	}
+
+	return nil
+}
+
+func (r tableRef) schemaOr(schema string) string {
+	if r.schema != "" {
+		return r.schema
+	}
+	return schema
+}
+
+func keywords(prefix string) []Candidate {
+	lower := prefix != "" && strings.ToLower(prefix) == prefix
+	candidates := make([]Candidate, 0, len(sqlparse.Keywords))
+	for _, k := range sqlparse.Keywords {
+		if lower {
+			k = strings.ToLower(k)
+		}
+		candidates = append(candidates, Candidate{Text: k, Kind: Keyword})
+	}
+	return candidates
+}
+
+func schemas(meta Metadata) []Candidate {
+	names, _ := meta.Schemas()
+	candidates := make([]Candidate, 0, len(names))
+	for _, name := range names {
+		candidates = append(candidates, Candidate{Text: name, Kind: Schema, Detail: Schema.String()})
+	}
+	return candidates
+}
+
+func tables(schema string, meta Metadata) []Candidate {
+	if schema == "" {
+		return nil
+	}
+	names, _ := meta.Tables(schema)
+	candidates := make([]Candidate, 0, len(names))
+	for _, name := range names {
+		candidates = append(candidates, Candidate{Text: name, Kind: Table, Detail: Table.String()})
+	}
+	return candidates
+}
+
+func tableColumns(schema, table string, meta Metadata) []Candidate {
+	columns, _ := meta.Columns(schema, table)
+	candidates := make([]Candidate, 0, len(columns))
+	for _, c := range columns {
+		detail := c.Type
+		if c.PrimaryKey {
+			detail += " PK"
+		}
+		candidates = append(candidates, Candidate{Text: c.Name, Kind: Column, Detail: detail})
+	}
+	return candidates
+}
+
+// columns returns the columns of every table the statement refers to, each column name once.
+func columns(schema string, refs []tableRef, meta Metadata) []Candidate {
+	var candidates []Candidate
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		for _, c := range tableColumns(ref.schemaOr(schema), ref.table, meta) {
+			if !seen[c.Text] {
+				seen[c.Text] = true
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	return candidates
+}
+
+// filter keeps the candidates starting with the prefix, regardless of case. Candidates keep their kind
+// order and are sorted by name within a kind, exact case matches first.
+func filter(candidates []Candidate, prefix string) []Candidate {
+	var result []Candidate
+	for _, c := range candidates {
+		if len(c.Text) >= len(prefix) && strings.EqualFold(c.Text[:len(prefix)], prefix) && c.Text != prefix {
+			result = append(result, c)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Kind != b.Kind {
+			return kindOrder(a.Kind) < kindOrder(b.Kind)
+		}
+		return strings.ToLower(a.Text) < strings.ToLower(b.Text)
+	})
+
+	return result
+}
+
+// kindOrder ranks names from the database above keywords.
+func kindOrder(k Kind) int {
+	return [...]int{Keyword: 3, Schema: 2, Table: 1, Column: 0}[k]
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
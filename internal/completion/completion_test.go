@@ -0,0 +1,88 @@
+package completion
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMetadata struct{}
+
+func (fakeMetadata) Schemas() ([]string, bool) {
+	return []string{"employees", "world"}, true
+}
+
+func (fakeMetadata) Tables(schema string) ([]string, bool) {
+	switch schema {
+	case "employees":
+		return []string{"departments", "dept_emp", "employees", "salaries"}, true
+	case "world":
+		return []string{"city", "country"}, true
+	}
+	return nil, false
+}
+
+func (fakeMetadata) Columns(schema, table string) ([]internal.Column, bool) {
+	switch schema + "." + table {
+	case "employees.departments":
+		return []internal.Column{{Name: "dept_no", Type: "char(4)", PrimaryKey: true}, {Name: "dept_name", Type: "varchar(40)"}}, true
+	case "employees.dept_emp":
+		return []internal.Column{{Name: "emp_no", Type: "int", PrimaryKey: true}, {Name: "dept_no", Type: "char(4)", PrimaryKey: true}}, true
+	case "world.city":
+		return []internal.Column{{Name: "id", Type: "int", PrimaryKey: true}, {Name: "name", Type: "text"}}, true
+	}
+	return nil, false
+}
+
+// complete runs Complete with the cursor at the `|` marker.
+func complete(t *testing.T, text string) (string, []string) {
+	offset := strings.Index(text, "|")
+	text = strings.Replace(text, "|", "", 1)
+
+	from, candidates := Complete(text, offset, "employees", fakeMetadata{})
+	var names []string
+	for _, c := range candidates {
+		names = append(names, c.Text)
+	}
+	return text[from:offset], names
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name, text, prefix string
+		want               []string
+	}{
+		{"tables after FROM", "select * from dep|", "dep", []string{"departments", "dept_emp"}},
+		{"tables and schemas after FROM", "SELECT * FROM |", "", []string{"departments", "dept_emp", "employees", "salaries", "employees", "world"}},
+		{"tables after JOIN", "select * from salaries s join d|", "d", []string{"departments", "dept_emp"}},
+		{"tables after a comma in FROM", "select * from salaries, s|", "s", []string{"salaries"}},
+		{"tables of a schema", "select * from world.c|", "c", []string{"city", "country"}},
+		{"columns of an alias", "select d.| from departments d", "", []string{"dept_name", "dept_no"}},
+		{"columns of a schema qualified alias", "select c.n| from world.city AS c", "n", []string{"name"}},
+		{"columns of a table name", "select * from dept_emp where dept_emp.e|", "e", []string{"emp_no"}},
+		{"columns of a table without alias", "select * from employees where departments.|", "", []string{"dept_name", "dept_no"}},
+		{"columns of all tables", "select de| from departments d join dept_emp de on d.dept_no = de.dept_no", "de", []string{"dept_name", "dept_no", "default", "delete", "desc", "describe"}},
+		{"lower case keywords", "sel|", "sel", []string{"select"}},
+		{"schemas after USE", "use w|", "w", []string{"world"}},
+		{"current statement only", "select * from world.city c; select c.|", "", nil},
+		{"nothing in strings", "select 'abc|'", "", nil},
+		{"nothing in comments", "select 1 -- fr|", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, got := complete(t, tt.text)
+			assert.Equal(t, tt.prefix, prefix)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestComplete_Details(t *testing.T) {
+	_, candidates := Complete("select * from departments where dept_n", 38, "employees", fakeMetadata{})
+	assert.Equal(t, []Candidate{
+		{Text: "dept_name", Kind: Column, Detail: "varchar(40)"},
+		{Text: "dept_no", Kind: Column, Detail: "char(4) PK"},
+	}, candidates)
+}
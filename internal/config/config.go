@@ -9,6 +9,12 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// MaxIndent is the widest indentation the query editor accepts.
+const MaxIndent = 16
+
+// MaxPageSize is the largest number of rows a preview page may hold.
+const MaxPageSize = 10000
+
 type (
 	// AppConfig implements the same-named interface and holds information about app-level configuration.
 	AppConfig struct {
@@ -16,6 +22,20 @@ type (
 		DataSourcesProp []DataSourceConfig `yaml:"dataSources"`
 		// DefaultProp is used to parse the alias for the default connection.
 		DefaultProp string `yaml:"default"`
+		// IncludeProp is used to parse the list of configuration files merged into this one.
+		IncludeProp []string `yaml:"include"`
+		// KeysProp is used to parse the key bindings overriding the default ones.
+		KeysProp map[string]string `yaml:"keys"`
+		// ThemeProp is used to parse the name of the color theme.
+		ThemeProp string `yaml:"theme"`
+		// ThemesProp is used to parse custom color palettes by theme name.
+		ThemesProp map[string]map[string]string `yaml:"themes"`
+		// EditorProp is used to parse the query editor settings.
+		EditorProp *EditorConfig `yaml:"editor"`
+		// SnippetsProp is used to parse the directory of saved queries.
+		SnippetsProp string `yaml:"snippets"`
+		// PageSizeProp is used to parse the number of rows per preview page.
+		PageSizeProp int `yaml:"pageSize"`
 	}
 	// DataSourceConfig keeps configuration parameters for a single data source connection.
 	DataSourceConfig struct {
@@ -25,6 +45,30 @@ type (
 		TypeProp string `yaml:"type"`
 		// DSNProp parses DSN parameter for a data source.
 		DSNProp string `yaml:"dsn"`
+		// TLSProp parses optional TLS parameters for a data source.
+		TLSProp *TLSConfig `yaml:"tls"`
+		// ConfirmProp parses the classes of statements which need confirmation before running.
+		ConfirmProp []string `yaml:"confirm"`
+	}
+	// TLSConfig keeps TLS parameters for a single data source connection.
+	TLSConfig struct {
+		// CAFileProp parses the path to the certificate authority file.
+		CAFileProp string `yaml:"caFile"`
+		// CertFileProp parses the path to the client certificate file.
+		CertFileProp string `yaml:"certFile"`
+		// KeyFileProp parses the path to the client key file.
+		KeyFileProp string `yaml:"keyFile"`
+		// SkipVerifyProp parses the flag disabling server certificate verification.
+		SkipVerifyProp bool `yaml:"skipVerify"`
+		// ServerNameProp parses the server name used for certificate verification.
+		ServerNameProp string `yaml:"serverName"`
+	}
+	// EditorConfig keeps the query editor settings.
+	EditorConfig struct {
+		// IndentProp parses the indentation width in columns.
+		IndentProp int `yaml:"indent"`
+		// TabsProp parses the flag indenting with tabs instead of spaces.
+		TabsProp bool `yaml:"tabs"`
 	}
 )
 
@@ -59,6 +103,40 @@ func (ac AppConfig) Default() string {
 	return ac.DefaultProp
 }
 
+// Keys returns Keys property from the configuration file.
+func (ac AppConfig) Keys() map[string]string {
+	return ac.KeysProp
+}
+
+// Theme returns Theme property from the configuration file.
+func (ac AppConfig) Theme() string {
+	return ac.ThemeProp
+}
+
+// Themes returns Themes property from the configuration file.
+func (ac AppConfig) Themes() map[string]map[string]string {
+	return ac.ThemesProp
+}
+
+// Editor returns Editor property from the configuration file, or nil if the section is absent.
+func (ac AppConfig) Editor() internal.EditorConfig {
+	if ac.EditorProp == nil {
+		return nil
+	}
+
+	return ac.EditorProp
+}
+
+// Snippets returns Snippets property from the configuration file. Load resolves it relative to the file setting it.
+func (ac AppConfig) Snippets() string {
+	return ac.SnippetsProp
+}
+
+// PageSize returns PageSize property from the configuration file, or 0 if it is not set.
+func (ac AppConfig) PageSize() int {
+	return ac.PageSizeProp
+}
+
 // Alias returns Alias property from the configuration file.
 func (dsc DataSourceConfig) Alias() string {
 	return dsc.AliasProp
@@ -73,3 +151,52 @@ func (dsc DataSourceConfig) Type() string {
 func (dsc DataSourceConfig) DSN() string {
 	return dsc.DSNProp
 }
+
+// TLS returns TLS property from the configuration file, or nil if the section is absent.
+func (dsc DataSourceConfig) TLS() internal.TLSConfig {
+	if dsc.TLSProp == nil {
+		return nil
+	}
+
+	return dsc.TLSProp
+}
+
+// Confirm returns Confirm property from the configuration file, or nil if it is absent.
+func (dsc DataSourceConfig) Confirm() []string {
+	return dsc.ConfirmProp
+}
+
+// CAFile returns CAFile property from the configuration file.
+func (tc *TLSConfig) CAFile() string {
+	return tc.CAFileProp
+}
+
+// CertFile returns CertFile property from the configuration file.
+func (tc *TLSConfig) CertFile() string {
+	return tc.CertFileProp
+}
+
+// KeyFile returns KeyFile property from the configuration file.
+func (tc *TLSConfig) KeyFile() string {
+	return tc.KeyFileProp
+}
+
+// SkipVerify returns SkipVerify property from the configuration file.
+func (tc *TLSConfig) SkipVerify() bool {
+	return tc.SkipVerifyProp
+}
+
+// ServerName returns ServerName property from the configuration file.
+func (tc *TLSConfig) ServerName() string {
+	return tc.ServerNameProp
+}
+
+// Indent returns Indent property from the configuration file.
+func (ec *EditorConfig) Indent() int {
+	return ec.IndentProp
+}
+
+// Tabs returns Tabs property from the configuration file.
+func (ec *EditorConfig) Tabs() bool {
+	return ec.TabsProp
+}
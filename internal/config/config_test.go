@@ -39,3 +39,28 @@ func TestAppConfig_DataSourceConfigs(t *testing.T) {
 	assert.Equal(t, "postgresql", worldDBConfig.Type())
 	assert.Equal(t, "user=world password=world123 host=localhost port=5432 dbname=world-db sslmode=disable", worldDBConfig.DSN())
 }
+
+func TestDataSourceConfig_TLS(t *testing.T) {
+	appConfig, err := New("testdata/tls-dbui.yml")
+	assert.Nil(t, err)
+
+	tlsConfig := appConfig.DataSourceConfigs()["secure-mysql"].TLS()
+	assert.NotNil(t, tlsConfig)
+	assert.Equal(t, "/etc/dbui/ca.pem", tlsConfig.CAFile())
+	assert.Equal(t, "/etc/dbui/client-cert.pem", tlsConfig.CertFile())
+	assert.Equal(t, "/etc/dbui/client-key.pem", tlsConfig.KeyFile())
+	assert.Equal(t, "db.internal", tlsConfig.ServerName())
+	assert.False(t, tlsConfig.SkipVerify())
+
+	assert.Nil(t, appConfig.DataSourceConfigs()["plain-postgresql"].TLS())
+}
+
+func TestDataSourceConfig_Confirm(t *testing.T) {
+	appConfig, err := New("testdata/confirm-dbui.yml")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"drop", "truncate"}, appConfig.DataSourceConfigs()["production"].Confirm())
+	assert.NotNil(t, appConfig.DataSourceConfigs()["scratch"].Confirm())
+	assert.Empty(t, appConfig.DataSourceConfigs()["scratch"].Confirm())
+	assert.Nil(t, appConfig.DataSourceConfigs()["local"].Confirm())
+}
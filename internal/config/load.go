@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// LocalFile is the project-local configuration file, looked up in the current directory.
+	LocalFile = ".dbui.yml"
+	// LegacyFile is the configuration file name used before the XDG layout, looked up in the current and home directories.
+	LegacyFile = "dbui.yml"
+)
+
+// ErrConfigNotFound indicates that none of the known configuration locations contains a file.
+var ErrConfigNotFound = errors.New("no configuration file found; create $XDG_CONFIG_HOME/dbui/config.yml or ./.dbui.yml, or use -dsn and -type")
+
+// Dir returns the user-wide configuration directory, $XDG_CONFIG_HOME/dbui.
+// XDG_CONFIG_HOME falls back to ~/.config on every platform, as most terminal tools do.
+func Dir() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "dbui"), nil
+}
+
+// DataDir returns the directory the data dbui keeps between runs is stored in, $XDG_DATA_HOME/dbui.
+// XDG_DATA_HOME falls back to ~/.local/share on every platform, like XDG_CONFIG_HOME does for Dir.
+func DataDir() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+
+	return filepath.Join(dir, "dbui"), nil
+}
+
+// GlobalFile returns the path of the user-wide configuration file, $XDG_CONFIG_HOME/dbui/config.yml.
+func GlobalFile() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "config.yml"), nil
+}
+
+// Discover returns the configuration files to load when no custom file is given, in merge order.
+// The global file is the first existing one of $XDG_CONFIG_HOME/dbui/config.yml and ~/dbui.yml.
+// The project-local file is the first existing one of ./.dbui.yml and ./dbui.yml.
+func Discover() ([]string, error) {
+	var globals, locals []string
+
+	if global, err := GlobalFile(); err == nil {
+		globals = append(globals, global)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		globals = append(globals, filepath.Join(home, LegacyFile))
+	}
+	locals = append(locals, LocalFile, LegacyFile)
+
+	var files []string
+	for _, candidates := range [][]string{globals, locals} {
+		if file := firstExisting(candidates); file != "" && !containsSameFile(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, ErrConfigNotFound
+	}
+
+	return files, nil
+}
+
+// Load reads the provided configuration files together with their includes and merges them in order.
+// Data sources of later files override the ones with the same alias from earlier files,
+// and a non-empty default of a later file replaces the earlier one.
+func Load(files ...string) (*AppConfig, error) {
+	merged := &AppConfig{}
+	for _, file := range files {
+		if err := loadInto(merged, file, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return merged, nil
+}
+
+// loadInto merges the includes of file first and the file itself afterwards, so the including file wins.
+func loadInto(dst *AppConfig, file string, chain []string) error {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	for _, f := range chain {
+		if f == abs {
+			return fmt.Errorf("include cycle: %s", strings.Join(append(chain, abs), " -> "))
+		}
+	}
+	chain = append(chain, abs)
+
+	src, err := New(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	for _, include := range src.IncludeProp {
+		if err := loadInto(dst, resolvePath(filepath.Dir(abs), include), chain); err != nil {
+			return err
+		}
+	}
+
+	if src.SnippetsProp != "" {
+		src.SnippetsProp = resolvePath(filepath.Dir(abs), src.SnippetsProp)
+	}
+	dst.merge(src)
+	return nil
+}
+
+func (ac *AppConfig) merge(src *AppConfig) {
+	for _, dsc := range src.DataSourcesProp {
+		replaced := false
+		for i := range ac.DataSourcesProp {
+			if ac.DataSourcesProp[i].AliasProp == dsc.AliasProp {
+				ac.DataSourcesProp[i] = dsc
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			ac.DataSourcesProp = append(ac.DataSourcesProp, dsc)
+		}
+	}
+
+	if src.DefaultProp != "" {
+		ac.DefaultProp = src.DefaultProp
+	}
+
+	if src.EditorProp != nil {
+		ac.EditorProp = src.EditorProp
+	}
+
+	if src.SnippetsProp != "" {
+		ac.SnippetsProp = src.SnippetsProp
+	}
+
+	if src.PageSizeProp != 0 {
+		ac.PageSizeProp = src.PageSizeProp
+	}
+
+	if src.ThemeProp != "" {
+		ac.ThemeProp = src.ThemeProp
+	}
+	for name, palette := range src.ThemesProp {
+		if ac.ThemesProp == nil {
+			ac.ThemesProp = map[string]map[string]string{}
+		}
+		ac.ThemesProp[name] = palette
+	}
+
+	for action, keys := range src.KeysProp {
+		if ac.KeysProp == nil {
+			ac.KeysProp = map[string]string{}
+		}
+		ac.KeysProp[action] = keys
+	}
+}
+
+// resolvePath expands a leading ~ and makes relative paths relative to dir.
+func resolvePath(dir, path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	return path
+}
+
+func firstExisting(candidates []string) string {
+	for _, c := range candidates {
+		if info, err := os.Stat(c); err == nil && !info.IsDir() {
+			return c
+		}
+	}
+
+	return ""
+}
+
+// containsSameFile guards against loading ~/dbui.yml twice when dbui runs from the home directory.
+func containsSameFile(files []string, file string) bool {
+	info, err := os.Stat(file)
+	if err != nil {
+		return false
+	}
+	for _, f := range files {
+		if other, err := os.Stat(f); err == nil && os.SameFile(info, other) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	appConfig, err := Load("testdata/layered/global.yml", "testdata/layered/project/.dbui.yml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "project", appConfig.Default())
+	dscs := appConfig.DataSourceConfigs()
+	assert.Len(t, dscs, 4)
+	assert.Equal(t, "/home/me/notes.db", dscs["personal"].DSN(), "including file overrides its includes")
+	assert.Equal(t, "app:app@(staging:3306)/app", dscs["staging"].DSN())
+	assert.Equal(t, "host=localhost dbname=analytics", dscs["analytics"].DSN(), "local file overrides the global one")
+	assert.Equal(t, "project.db", dscs["project"].DSN())
+
+	var aliases []string
+	for _, dsc := range appConfig.DataSourcesProp {
+		aliases = append(aliases, dsc.AliasProp)
+	}
+	assert.Equal(t, []string{"staging", "personal", "analytics", "project"}, aliases, "overrides keep their original position")
+
+	assert.Equal(t, map[string]string{"reload": "Ctrl-R", "describeTable": "d"}, appConfig.Keys(), "key bindings merge per action")
+
+	assert.Equal(t, "mine", appConfig.Theme(), "local file selects a theme defined globally")
+	assert.Equal(t, map[string]map[string]string{"mine": {"base": "dark", "header": "orange"}}, appConfig.Themes())
+
+	require.NotNil(t, appConfig.Editor())
+	assert.Equal(t, 4, appConfig.Editor().Indent())
+	assert.False(t, appConfig.Editor().Tabs())
+
+	abs, err := filepath.Abs("testdata/layered/project/queries")
+	require.NoError(t, err)
+	assert.Equal(t, abs, appConfig.Snippets(), "the snippets directory is relative to the file setting it")
+}
+
+func TestLoad_Negative(t *testing.T) {
+	_, err := Load("testdata/layered/cycle-a.yml")
+	assert.ErrorContains(t, err, "include cycle")
+
+	_, err = Load("testdata/success-dbui.yml", "testdata/corrupt-dbui.yml")
+	assert.ErrorContains(t, err, "testdata/corrupt-dbui.yml: yaml: line 3")
+
+	_, err = Load("testdata/ghost-dbui.yml")
+	assert.Error(t, err)
+}
+
+func TestDiscover(t *testing.T) {
+	home, xdg, project := t.TempDir(), t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Chdir(project)
+
+	_, err := Discover()
+	assert.ErrorIs(t, err, ErrConfigNotFound)
+
+	touch(t, filepath.Join(home, LegacyFile))
+	files, err := Discover()
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(home, LegacyFile)}, files)
+
+	global := filepath.Join(xdg, "dbui", "config.yml")
+	touch(t, global)
+	touch(t, LegacyFile)
+	files, err = Discover()
+	require.NoError(t, err)
+	assert.Equal(t, []string{global, LegacyFile}, files, "XDG file wins over ~/dbui.yml")
+
+	touch(t, LocalFile)
+	files, err = Discover()
+	require.NoError(t, err)
+	assert.Equal(t, []string{global, LocalFile}, files, ".dbui.yml wins over ./dbui.yml")
+}
+
+func TestDiscover_HomeDirectory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Chdir(home)
+
+	touch(t, LegacyFile)
+	files, err := Discover()
+	require.NoError(t, err)
+	assert.Len(t, files, 1, "~/dbui.yml must not be merged with itself")
+}
+
+func touch(t *testing.T, file string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+	require.NoError(t, os.WriteFile(file, []byte("dataSources: []\n"), 0o600))
+}
+
+func TestDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	dir, err := Dir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".config", "dbui"), dir)
+
+	file, err := GlobalFile()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "config.yml"), file)
+}
+
+func TestDataDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+
+	dir, err := DataDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "share", "dbui"), dir)
+
+	t.Setenv("XDG_DATA_HOME", "/data")
+	dir, err = DataDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/data", "dbui"), dir)
+}
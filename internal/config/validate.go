@@ -0,0 +1,360 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Diagnostic describes a single problem found in a configuration file.
+	Diagnostic struct {
+		File    string
+		Line    int
+		Column  int
+		Message string
+	}
+
+	// Rules provides the engine specific knowledge configuration files are validated against.
+	Rules struct {
+		// Types lists the supported data source types.
+		Types []string
+		// DSN reports whether dsn is well-formed for a data source of the given type.
+		DSN func(typ, dsn string) error
+		// KeyBinding reports whether an entry of the keys section names a known action and valid keys.
+		KeyBinding func(action, keys string) error
+		// Theme reports whether the theme exists among the built-in and the custom palettes, and whether the palettes are valid.
+		Theme func(name string, palettes map[string]map[string]string) error
+		// StatementClasses lists the classes of statements which can need confirmation.
+		StatementClasses []string
+	}
+)
+
+// String formats the diagnostic as file:line:column: message, leaving out unknown positions.
+func (d Diagnostic) String() string {
+	switch {
+	case d.Line == 0:
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	case d.Column == 0:
+		return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+	default:
+		return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+	}
+}
+
+// yamlErrLine extracts the line number yaml.v3 embeds into syntax error messages.
+var yamlErrLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+type validator struct {
+	rules       Rules
+	diagnostics []Diagnostic
+	aliases     map[string]bool
+	defaultFile string
+	defaultNode *yaml.Node
+	themeName   string
+	themeFile   string
+	themeNode   *yaml.Node
+	themes      map[string]map[string]string
+}
+
+// Validate checks the provided configuration files and their includes the same way Load merges them,
+// and returns every problem found. An empty result means the files are valid.
+func Validate(rules Rules, files ...string) []Diagnostic {
+	v := &validator{rules: rules, aliases: map[string]bool{}, themes: map[string]map[string]string{}}
+	for _, file := range files {
+		v.file(file, nil)
+	}
+
+	if v.themeNode != nil && v.rules.Theme != nil {
+		if err := v.rules.Theme(v.themeName, v.themes); err != nil {
+			v.report(v.themeFile, v.themeNode, "%v", err)
+		}
+	}
+
+	if v.defaultNode != nil && !v.aliases[v.defaultNode.Value] {
+		v.report(v.defaultFile, v.defaultNode, "default %q does not match any data source alias", v.defaultNode.Value)
+	}
+	if len(v.aliases) == 0 && len(v.diagnostics) == 0 && len(files) > 0 {
+		v.diagnostics = append(v.diagnostics, Diagnostic{File: files[len(files)-1], Message: "no data sources defined"})
+	}
+
+	// Keep files in the order they were visited, and diagnostics of a file in reading order.
+	order := map[string]int{}
+	for _, d := range v.diagnostics {
+		if _, ok := order[d.File]; !ok {
+			order[d.File] = len(order)
+		}
+	}
+	sort.SliceStable(v.diagnostics, func(i, j int) bool {
+		a, b := v.diagnostics[i], v.diagnostics[j]
+		if a.File != b.File {
+			return order[a.File] < order[b.File]
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return v.diagnostics
+}
+
+func (v *validator) report(file string, n *yaml.Node, format string, args ...interface{}) {
+	d := Diagnostic{File: file, Message: fmt.Sprintf(format, args...)}
+	if n != nil {
+		d.Line, d.Column = n.Line, n.Column
+	}
+	v.diagnostics = append(v.diagnostics, d)
+}
+
+func (v *validator) file(file string, chain []string) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		v.report(file, nil, "%v", err)
+		return
+	}
+	for _, f := range chain {
+		if f == abs {
+			v.report(file, nil, "include cycle: %s", strings.Join(append(chain, abs), " -> "))
+			return
+		}
+	}
+	chain = append(chain, abs)
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		v.report(file, nil, "%v", err)
+		return
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		d := Diagnostic{File: file, Message: err.Error()}
+		if m := yamlErrLine.FindStringSubmatch(err.Error()); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Message = m[2]
+		}
+		v.diagnostics = append(v.diagnostics, d)
+		return
+	}
+	if len(doc.Content) == 0 {
+		return
+	}
+
+	root := doc.Content[0]
+	v.schema(file, root, reflect.TypeOf(AppConfig{}), "")
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+
+	if includes := mappingValue(root, "include"); includes != nil && includes.Kind == yaml.SequenceNode {
+		for _, include := range includes.Content {
+			path := resolvePath(filepath.Dir(file), include.Value)
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				v.report(file, include, "included file %q does not exist", include.Value)
+				continue
+			}
+			v.file(path, chain)
+		}
+	}
+
+	if dataSources := mappingValue(root, "dataSources"); dataSources != nil && dataSources.Kind == yaml.SequenceNode {
+		v.dataSources(file, dataSources)
+	}
+
+	if keys := mappingValue(root, "keys"); keys != nil && keys.Kind == yaml.MappingNode && v.rules.KeyBinding != nil {
+		for i := 0; i+1 < len(keys.Content); i += 2 {
+			if err := v.rules.KeyBinding(keys.Content[i].Value, keys.Content[i+1].Value); err != nil {
+				v.report(file, keys.Content[i+1], "keys.%s: %v", keys.Content[i].Value, err)
+			}
+		}
+	}
+
+	if editor := mappingValue(root, "editor"); editor != nil && editor.Kind == yaml.MappingNode {
+		if indent := mappingValue(editor, "indent"); indent != nil {
+			if n, err := strconv.Atoi(indent.Value); err == nil && (n < 1 || n > MaxIndent) {
+				v.report(file, indent, "editor.indent must be between 1 and %d", MaxIndent)
+			}
+		}
+	}
+
+	if pageSize := mappingValue(root, "pageSize"); pageSize != nil {
+		if n, err := strconv.Atoi(pageSize.Value); err == nil && (n < 1 || n > MaxPageSize) {
+			v.report(file, pageSize, "pageSize must be between 1 and %d", MaxPageSize)
+		}
+	}
+
+	// Themes are checked once all files are merged, as a theme may be defined in another file than the one selecting it.
+	if themes := mappingValue(root, "themes"); themes != nil {
+		var palettes map[string]map[string]string
+		if themes.Decode(&palettes) == nil {
+			for name, palette := range palettes {
+				v.themes[name] = palette
+			}
+		}
+		v.themeFile, v.themeNode = file, themes
+	}
+	if theme := mappingValue(root, "theme"); theme != nil && theme.Kind == yaml.ScalarNode {
+		v.themeName, v.themeFile, v.themeNode = theme.Value, file, theme
+	}
+
+	if def := mappingValue(root, "default"); def != nil && def.Kind == yaml.ScalarNode && def.Value != "" {
+		v.defaultFile, v.defaultNode = file, def
+	}
+}
+
+func (v *validator) dataSources(file string, seq *yaml.Node) {
+	seen := map[string]*yaml.Node{}
+	for _, item := range seq.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+
+		alias, typ, dsn := mappingValue(item, "alias"), mappingValue(item, "type"), mappingValue(item, "dsn")
+		for _, field := range []struct {
+			name string
+			node *yaml.Node
+		}{{"alias", alias}, {"type", typ}, {"dsn", dsn}} {
+			if field.node == nil || field.node.Value == "" {
+				v.report(file, item, "data source is missing %q", field.name)
+			}
+		}
+
+		if alias != nil && alias.Value != "" {
+			if first, ok := seen[alias.Value]; ok {
+				v.report(file, alias, "duplicate alias %q, first defined at line %d", alias.Value, first.Line)
+			} else {
+				seen[alias.Value] = alias
+			}
+			v.aliases[alias.Value] = true
+		}
+
+		if confirm := mappingValue(item, "confirm"); confirm != nil && confirm.Kind == yaml.SequenceNode && v.rules.StatementClasses != nil {
+			for _, class := range confirm.Content {
+				if !slices.Contains(v.rules.StatementClasses, class.Value) {
+					v.report(file, class, "unknown statement class %q, expected one of: %s", class.Value, strings.Join(v.rules.StatementClasses, ", "))
+				}
+			}
+		}
+
+		if typ == nil || typ.Value == "" {
+			continue
+		}
+		if !slices.Contains(v.rules.Types, typ.Value) {
+			v.report(file, typ, "unknown type %q, expected one of: %s", typ.Value, strings.Join(v.rules.Types, ", "))
+			continue
+		}
+		if dsn != nil && dsn.Value != "" && v.rules.DSN != nil {
+			if err := v.rules.DSN(typ.Value, dsn.Value); err != nil {
+				v.report(file, dsn, "malformed %s dsn: %v", typ.Value, err)
+			}
+		}
+	}
+}
+
+// schema walks the node along the Go type it is parsed into and reports unknown keys and mismatching kinds.
+func (v *validator) schema(file string, n *yaml.Node, t reflect.Type, path string) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n.ShortTag() == "!!null" {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			v.report(file, n, "%s must be a mapping", describe(path))
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				v.report(file, key, "unknown key %q in %s", key.Value, describe(path))
+				continue
+			}
+			v.schema(file, value, field.Type, join(path, key.Value))
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			v.report(file, n, "%s must be a mapping", describe(path))
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v.schema(file, n.Content[i+1], t.Elem(), join(path, n.Content[i].Value))
+		}
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			v.report(file, n, "%s must be a list", describe(path))
+			return
+		}
+		for i, item := range n.Content {
+			v.schema(file, item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Bool:
+		if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!bool" {
+			v.report(file, n, "%s must be true or false", describe(path))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!int" {
+			v.report(file, n, "%s must be an integer", describe(path))
+		}
+	case reflect.String:
+		if n.Kind != yaml.ScalarNode {
+			v.report(file, n, "%s must be a string", describe(path))
+		}
+	}
+}
+
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f
+	}
+
+	return fields
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func describe(path string) string {
+	if path == "" {
+		return "configuration"
+	}
+
+	return path
+}
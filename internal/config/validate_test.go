@@ -0,0 +1,74 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRules = Rules{
+	Types: []string{"mysql", "postgresql", "sqlite"},
+	DSN: func(typ, dsn string) error {
+		if typ == "mysql" && dsn == "root:demo@(localhost:3316" {
+			return errors.New("missing closing bracket")
+		}
+		return nil
+	},
+	KeyBinding: func(action, keys string) error {
+		if keys == "Ctrl-Nope" {
+			return errors.New(`unknown key "Ctrl-Nope"`)
+		}
+		return nil
+	},
+	Theme: func(name string, palettes map[string]map[string]string) error {
+		if _, ok := palettes[name]; !ok && name != "dark" {
+			return fmt.Errorf("unknown theme %q", name)
+		}
+		return nil
+	},
+	StatementClasses: []string{"delete", "update", "drop", "truncate", "alter"},
+}
+
+func TestValidate(t *testing.T) {
+	diagnostics := Validate(testRules, "testdata/validate/valid-dbui.yml")
+	assert.Empty(t, diagnostics, "default may point to an alias of an included file")
+
+	var got []string
+	for _, d := range Validate(testRules, "testdata/validate/invalid-dbui.yml") {
+		got = append(got, d.String())
+	}
+	assert.Equal(t, []string{
+		`testdata/validate/invalid-dbui.yml:2:5: included file "missing.yml" does not exist`,
+		`testdata/validate/invalid-dbui.yml:6:10: malformed mysql dsn: missing closing bracket`,
+		`testdata/validate/invalid-dbui.yml:7:12: duplicate alias "employees", first defined at line 4`,
+		`testdata/validate/invalid-dbui.yml:8:11: unknown type "mongodb", expected one of: mysql, postgresql, sqlite`,
+		`testdata/validate/invalid-dbui.yml:10:5: data source is missing "dsn"`,
+		`testdata/validate/invalid-dbui.yml:12:5: unknown key "dns" in dataSources[2]`,
+		`testdata/validate/invalid-dbui.yml:14:19: dataSources[2].tls.skipVerify must be true or false`,
+		`testdata/validate/invalid-dbui.yml:15:21: unknown statement class "insert", expected one of: delete, update, drop, truncate, alter`,
+		`testdata/validate/invalid-dbui.yml:16:10: default "world-db" does not match any data source alias`,
+		`testdata/validate/invalid-dbui.yml:18:11: keys.reload: unknown key "Ctrl-Nope"`,
+		`testdata/validate/invalid-dbui.yml:19:8: unknown theme "ghost"`,
+		`testdata/validate/invalid-dbui.yml:21:11: editor.indent must be between 1 and 16`,
+		`testdata/validate/invalid-dbui.yml:22:11: pageSize must be between 1 and 10000`,
+	}, got)
+}
+
+func TestValidate_Files(t *testing.T) {
+	diagnostics := Validate(testRules, "testdata/corrupt-dbui.yml")
+	assert.Equal(t, []Diagnostic{{
+		File:    "testdata/corrupt-dbui.yml",
+		Line:    3,
+		Message: "mapping values are not allowed in this context",
+	}}, diagnostics)
+
+	diagnostics = Validate(testRules, "testdata/ghost-dbui.yml")
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, 0, diagnostics[0].Line)
+
+	diagnostics = Validate(testRules, "testdata/layered/cycle-a.yml")
+	assert.Len(t, diagnostics, 1)
+	assert.Contains(t, diagnostics[0].Message, "include cycle")
+}
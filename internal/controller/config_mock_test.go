@@ -68,6 +68,90 @@ func (mr *MockAppConfigMockRecorder) Default() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Default", reflect.TypeOf((*MockAppConfig)(nil).Default))
 }
 
+// Editor mocks base method.
+func (m *MockAppConfig) Editor() internal.EditorConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Editor")
+	ret0, _ := ret[0].(internal.EditorConfig)
+	return ret0
+}
+
+// Editor indicates an expected call of Editor.
+func (mr *MockAppConfigMockRecorder) Editor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Editor", reflect.TypeOf((*MockAppConfig)(nil).Editor))
+}
+
+// Keys mocks base method.
+func (m *MockAppConfig) Keys() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Keys")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockAppConfigMockRecorder) Keys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockAppConfig)(nil).Keys))
+}
+
+// PageSize mocks base method.
+func (m *MockAppConfig) PageSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PageSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// PageSize indicates an expected call of PageSize.
+func (mr *MockAppConfigMockRecorder) PageSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PageSize", reflect.TypeOf((*MockAppConfig)(nil).PageSize))
+}
+
+// Snippets mocks base method.
+func (m *MockAppConfig) Snippets() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snippets")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Snippets indicates an expected call of Snippets.
+func (mr *MockAppConfigMockRecorder) Snippets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snippets", reflect.TypeOf((*MockAppConfig)(nil).Snippets))
+}
+
+// Theme mocks base method.
+func (m *MockAppConfig) Theme() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Theme")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Theme indicates an expected call of Theme.
+func (mr *MockAppConfigMockRecorder) Theme() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Theme", reflect.TypeOf((*MockAppConfig)(nil).Theme))
+}
+
+// Themes mocks base method.
+func (m *MockAppConfig) Themes() map[string]map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Themes")
+	ret0, _ := ret[0].(map[string]map[string]string)
+	return ret0
+}
+
+// Themes indicates an expected call of Themes.
+func (mr *MockAppConfigMockRecorder) Themes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Themes", reflect.TypeOf((*MockAppConfig)(nil).Themes))
+}
+
 // MockDataSourceConfig is a mock of DataSourceConfig interface.
 type MockDataSourceConfig struct {
 	ctrl     *gomock.Controller
@@ -106,6 +190,20 @@ func (mr *MockDataSourceConfigMockRecorder) Alias() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Alias", reflect.TypeOf((*MockDataSourceConfig)(nil).Alias))
 }
 
+// Confirm mocks base method.
+func (m *MockDataSourceConfig) Confirm() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockDataSourceConfigMockRecorder) Confirm() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockDataSourceConfig)(nil).Confirm))
+}
+
 // DSN mocks base method.
 func (m *MockDataSourceConfig) DSN() string {
 	m.ctrl.T.Helper()
@@ -120,6 +218,20 @@ func (mr *MockDataSourceConfigMockRecorder) DSN() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DSN", reflect.TypeOf((*MockDataSourceConfig)(nil).DSN))
 }
 
+// TLS mocks base method.
+func (m *MockDataSourceConfig) TLS() internal.TLSConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TLS")
+	ret0, _ := ret[0].(internal.TLSConfig)
+	return ret0
+}
+
+// TLS indicates an expected call of TLS.
+func (mr *MockDataSourceConfigMockRecorder) TLS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TLS", reflect.TypeOf((*MockDataSourceConfig)(nil).TLS))
+}
+
 // Type mocks base method.
 func (m *MockDataSourceConfig) Type() string {
 	m.ctrl.T.Helper()
@@ -134,6 +246,152 @@ func (mr *MockDataSourceConfigMockRecorder) Type() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Type", reflect.TypeOf((*MockDataSourceConfig)(nil).Type))
 }
 
+// MockTLSConfig is a mock of TLSConfig interface.
+type MockTLSConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockTLSConfigMockRecorder
+	isgomock struct{}
+}
+
+// MockTLSConfigMockRecorder is the mock recorder for MockTLSConfig.
+type MockTLSConfigMockRecorder struct {
+	mock *MockTLSConfig
+}
+
+// NewMockTLSConfig creates a new mock instance.
+func NewMockTLSConfig(ctrl *gomock.Controller) *MockTLSConfig {
+	mock := &MockTLSConfig{ctrl: ctrl}
+	mock.recorder = &MockTLSConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTLSConfig) EXPECT() *MockTLSConfigMockRecorder {
+	return m.recorder
+}
+
+// CAFile mocks base method.
+func (m *MockTLSConfig) CAFile() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CAFile")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CAFile indicates an expected call of CAFile.
+func (mr *MockTLSConfigMockRecorder) CAFile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CAFile", reflect.TypeOf((*MockTLSConfig)(nil).CAFile))
+}
+
+// CertFile mocks base method.
+func (m *MockTLSConfig) CertFile() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CertFile")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CertFile indicates an expected call of CertFile.
+func (mr *MockTLSConfigMockRecorder) CertFile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CertFile", reflect.TypeOf((*MockTLSConfig)(nil).CertFile))
+}
+
+// KeyFile mocks base method.
+func (m *MockTLSConfig) KeyFile() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyFile")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// KeyFile indicates an expected call of KeyFile.
+func (mr *MockTLSConfigMockRecorder) KeyFile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyFile", reflect.TypeOf((*MockTLSConfig)(nil).KeyFile))
+}
+
+// ServerName mocks base method.
+func (m *MockTLSConfig) ServerName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ServerName indicates an expected call of ServerName.
+func (mr *MockTLSConfigMockRecorder) ServerName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerName", reflect.TypeOf((*MockTLSConfig)(nil).ServerName))
+}
+
+// SkipVerify mocks base method.
+func (m *MockTLSConfig) SkipVerify() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipVerify")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SkipVerify indicates an expected call of SkipVerify.
+func (mr *MockTLSConfigMockRecorder) SkipVerify() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipVerify", reflect.TypeOf((*MockTLSConfig)(nil).SkipVerify))
+}
+
+// MockEditorConfig is a mock of EditorConfig interface.
+type MockEditorConfig struct {
+	ctrl     *gomock.Controller
+	recorder *MockEditorConfigMockRecorder
+	isgomock struct{}
+}
+
+// MockEditorConfigMockRecorder is the mock recorder for MockEditorConfig.
+type MockEditorConfigMockRecorder struct {
+	mock *MockEditorConfig
+}
+
+// NewMockEditorConfig creates a new mock instance.
+func NewMockEditorConfig(ctrl *gomock.Controller) *MockEditorConfig {
+	mock := &MockEditorConfig{ctrl: ctrl}
+	mock.recorder = &MockEditorConfigMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEditorConfig) EXPECT() *MockEditorConfigMockRecorder {
+	return m.recorder
+}
+
+// Indent mocks base method.
+func (m *MockEditorConfig) Indent() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Indent")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Indent indicates an expected call of Indent.
+func (mr *MockEditorConfigMockRecorder) Indent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Indent", reflect.TypeOf((*MockEditorConfig)(nil).Indent))
+}
+
+// Tabs mocks base method.
+func (m *MockEditorConfig) Tabs() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tabs")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Tabs indicates an expected call of Tabs.
+func (mr *MockEditorConfigMockRecorder) Tabs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tabs", reflect.TypeOf((*MockEditorConfig)(nil).Tabs))
+}
+
 // MockDataSource is a mock of DataSource interface.
 type MockDataSource struct {
 	ctrl     *gomock.Controller
@@ -158,6 +416,34 @@ func (m *MockDataSource) EXPECT() *MockDataSourceMockRecorder {
 	return m.recorder
 }
 
+// Begin mocks base method.
+func (m *MockDataSource) Begin() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockDataSourceMockRecorder) Begin() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockDataSource)(nil).Begin))
+}
+
+// Commit mocks base method.
+func (m *MockDataSource) Commit() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockDataSourceMockRecorder) Commit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockDataSource)(nil).Commit))
+}
+
 // DescribeTable mocks base method.
 func (m *MockDataSource) DescribeTable(schema, table string) ([][]*string, error) {
 	m.ctrl.T.Helper()
@@ -173,6 +459,80 @@ func (mr *MockDataSourceMockRecorder) DescribeTable(schema, table any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTable", reflect.TypeOf((*MockDataSource)(nil).DescribeTable), schema, table)
 }
 
+// EstimateRows mocks base method.
+func (m *MockDataSource) EstimateRows(schema, table string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateRows", schema, table)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateRows indicates an expected call of EstimateRows.
+func (mr *MockDataSourceMockRecorder) EstimateRows(schema, table any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateRows", reflect.TypeOf((*MockDataSource)(nil).EstimateRows), schema, table)
+}
+
+// Execute mocks base method.
+func (m *MockDataSource) Execute(schema string, statements []internal.Statement) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", schema, statements)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockDataSourceMockRecorder) Execute(schema, statements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockDataSource)(nil).Execute), schema, statements)
+}
+
+// InTransaction mocks base method.
+func (m *MockDataSource) InTransaction() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTransaction")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// InTransaction indicates an expected call of InTransaction.
+func (mr *MockDataSourceMockRecorder) InTransaction() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTransaction", reflect.TypeOf((*MockDataSource)(nil).InTransaction))
+}
+
+// ListColumns mocks base method.
+func (m *MockDataSource) ListColumns(schema, table string) ([]internal.Column, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListColumns", schema, table)
+	ret0, _ := ret[0].([]internal.Column)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListColumns indicates an expected call of ListColumns.
+func (mr *MockDataSourceMockRecorder) ListColumns(schema, table any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListColumns", reflect.TypeOf((*MockDataSource)(nil).ListColumns), schema, table)
+}
+
+// ListSchemaColumns mocks base method.
+func (m *MockDataSource) ListSchemaColumns(schema string) (map[string][]internal.Column, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchemaColumns", schema)
+	ret0, _ := ret[0].(map[string][]internal.Column)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchemaColumns indicates an expected call of ListSchemaColumns.
+func (mr *MockDataSourceMockRecorder) ListSchemaColumns(schema any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchemaColumns", reflect.TypeOf((*MockDataSource)(nil).ListSchemaColumns), schema)
+}
+
 // ListSchemas mocks base method.
 func (m *MockDataSource) ListSchemas() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +563,21 @@ func (mr *MockDataSourceMockRecorder) ListTables(schema any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTables", reflect.TypeOf((*MockDataSource)(nil).ListTables), schema)
 }
 
+// ListViews mocks base method.
+func (m *MockDataSource) ListViews(schema string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViews", schema)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViews indicates an expected call of ListViews.
+func (mr *MockDataSourceMockRecorder) ListViews(schema any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViews", reflect.TypeOf((*MockDataSource)(nil).ListViews), schema)
+}
+
 // Ping mocks base method.
 func (m *MockDataSource) Ping() error {
 	m.ctrl.T.Helper()
@@ -218,18 +593,18 @@ func (mr *MockDataSourceMockRecorder) Ping() *gomock.Call {
 }
 
 // PreviewTable mocks base method.
-func (m *MockDataSource) PreviewTable(schema, table string) ([][]*string, error) {
+func (m *MockDataSource) PreviewTable(schema, table string, preview internal.Preview) ([][]*string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PreviewTable", schema, table)
+	ret := m.ctrl.Call(m, "PreviewTable", schema, table, preview)
 	ret0, _ := ret[0].([][]*string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PreviewTable indicates an expected call of PreviewTable.
-func (mr *MockDataSourceMockRecorder) PreviewTable(schema, table any) *gomock.Call {
+func (mr *MockDataSourceMockRecorder) PreviewTable(schema, table, preview any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTable", reflect.TypeOf((*MockDataSource)(nil).PreviewTable), schema, table)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewTable", reflect.TypeOf((*MockDataSource)(nil).PreviewTable), schema, table, preview)
 }
 
 // Query mocks base method.
@@ -247,6 +622,35 @@ func (mr *MockDataSourceMockRecorder) Query(schema, query any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockDataSource)(nil).Query), schema, query)
 }
 
+// Rollback mocks base method.
+func (m *MockDataSource) Rollback() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockDataSourceMockRecorder) Rollback() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockDataSource)(nil).Rollback))
+}
+
+// ServerInfo mocks base method.
+func (m *MockDataSource) ServerInfo() (internal.ServerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerInfo")
+	ret0, _ := ret[0].(internal.ServerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerInfo indicates an expected call of ServerInfo.
+func (mr *MockDataSourceMockRecorder) ServerInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerInfo", reflect.TypeOf((*MockDataSource)(nil).ServerInfo))
+}
+
 // MockDataController is a mock of DataController interface.
 type MockDataController struct {
 	ctrl     *gomock.Controller
@@ -285,6 +689,20 @@ func (mr *MockDataControllerMockRecorder) Current() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Current", reflect.TypeOf((*MockDataController)(nil).Current))
 }
 
+// CurrentAlias mocks base method.
+func (m *MockDataController) CurrentAlias() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentAlias")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CurrentAlias indicates an expected call of CurrentAlias.
+func (mr *MockDataControllerMockRecorder) CurrentAlias() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentAlias", reflect.TypeOf((*MockDataController)(nil).CurrentAlias))
+}
+
 // List mocks base method.
 func (m *MockDataController) List() [][]string {
 	m.ctrl.T.Helper()
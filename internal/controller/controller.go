@@ -29,12 +29,32 @@ var (
 	ErrIncorrectDefaultAlias = errors.New("incorrect default database alias")
 )
 
+// SupportedTypes lists the data source types the controller can connect to.
+var SupportedTypes = []string{"dummy", "mysql", "postgresql", "sqlite"}
+
+// ValidateDSN checks that dsn is well-formed for the data source type without connecting to it.
+func ValidateDSN(typ, dsn string) error {
+	switch typ {
+	case "dummy":
+		return nil
+	case "mysql":
+		return mysql.ValidateDSN(dsn)
+	case "postgresql":
+		return postgresql.ValidateDSN(dsn)
+	case "sqlite":
+		return sqlite.ValidateDSN(dsn)
+	default:
+		return ErrUnsupportedDatabaseType
+	}
+}
+
 // Controller implements internal.DataController interface. It provides Switch, List, and Current methods used over a set of data source configurations.
 type Controller struct {
 	appConfig         internal.AppConfig
 	dataSourceConfigs map[string]internal.DataSourceConfig
 	connectionPool    map[string]internal.DataSource
 	current           internal.DataSource
+	currentAlias      string
 }
 
 func (c *Controller) getConnectionOrConnect(conn internal.DataSourceConfig) (internal.DataSource, error) {
@@ -50,14 +70,14 @@ func (c *Controller) getConnectionOrConnect(conn internal.DataSourceConfig) (int
 		c.connectionPool[conn.Alias()] = dbConn
 		return dbConn, nil
 	case "mysql":
-		dbConn, err := mysql.New(conn.DSN())
+		dbConn, err := mysql.NewWithTLS(conn.DSN(), conn.TLS())
 		if err != nil {
 			return nil, err
 		}
 		c.connectionPool[conn.Alias()] = dbConn
 		return dbConn, nil
 	case "postgresql":
-		dbConn, err := postgresql.New(conn.DSN())
+		dbConn, err := postgresql.NewWithTLS(conn.DSN(), conn.TLS())
 		if err != nil {
 			return nil, err
 		}
@@ -103,6 +123,7 @@ func New(appConfig internal.AppConfig) (c *Controller, err error) {
 	}
 
 	c.current, err = c.getConnectionOrConnect(defaultDSC)
+	c.currentAlias = defaultDSC.Alias()
 	return
 }
 
@@ -125,6 +146,7 @@ func (c *Controller) Switch(alias string) (err error) {
 	}
 
 	c.current, err = c.getConnectionOrConnect(c.dataSourceConfigs[alias])
+	c.currentAlias = alias
 	return
 }
 
@@ -132,3 +154,8 @@ func (c *Controller) Switch(alias string) (err error) {
 func (c *Controller) Current() internal.DataSource {
 	return c.current
 }
+
+// CurrentAlias returns the alias of the selected data source.
+func (c *Controller) CurrentAlias() string {
+	return c.currentAlias
+}
@@ -228,3 +228,29 @@ func TestController_Switch(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDSN(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		dsn     string
+		wantErr bool
+	}{
+		{"dummy", "dummy", "anything", false},
+		{"mysql", "mysql", "root:demo@(localhost:3316)/employees", false},
+		{"mysql malformed", "mysql", "root:demo@(localhost:3316", true},
+		{"postgresql", "postgresql", "user=world dbname=world-db sslmode=disable", false},
+		{"postgresql url", "postgresql", "postgres://world@localhost/world-db", false},
+		{"postgresql malformed", "postgresql", "user=world port=", true},
+		{"sqlite", "sqlite", "chinook.db", false},
+		{"sqlite empty", "sqlite", " ", true},
+		{"unsupported", "mycustomsql", "conn1_dsn", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateDSN(tt.typ, tt.dsn); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDSN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -13,6 +13,18 @@ type (
 		DataSourceConfigs() map[string]DataSourceConfig
 		// Default returns alias of the default DataSourceConfig, which must be as a default connection on application startup.
 		Default() string
+		// Keys returns user defined key bindings as a map of action names to whitespace separated key strokes.
+		Keys() map[string]string
+		// Theme returns the name of the color theme, either a built-in or a custom one.
+		Theme() string
+		// Themes returns user defined color palettes by theme name.
+		Themes() map[string]map[string]string
+		// Editor returns the query editor settings, or nil when none are configured.
+		Editor() EditorConfig
+		// Snippets returns the directory of the saved queries library, or an empty string for the default one.
+		Snippets() string
+		// PageSize returns the number of rows per preview page, or 0 for the default.
+		PageSize() int
 	}
 	// DataSourceConfig sets interface for defining connection params to the data source.
 	DataSourceConfig interface {
@@ -22,6 +34,32 @@ type (
 		Type() string
 		// DSN returns the data source name, which the selected data source driver uses to establish a connection.
 		DSN() string
+		// TLS returns the TLS settings for the connection, or nil when none are configured.
+		TLS() TLSConfig
+		// Confirm returns the classes of statements which need confirmation before running, e.g. drop, or nil when
+		// none are configured and every class needs it. An empty list turns confirmation off.
+		Confirm() []string
+	}
+	// TLSConfig sets interface for the TLS parameters of a data source connection.
+	TLSConfig interface {
+		// CAFile returns the path to the PEM encoded certificate authority used to verify the server.
+		CAFile() string
+		// CertFile returns the path to the PEM encoded client certificate.
+		CertFile() string
+		// KeyFile returns the path to the PEM encoded client private key.
+		KeyFile() string
+		// SkipVerify reports whether the server certificate verification must be skipped.
+		SkipVerify() bool
+		// ServerName overrides the host name used to verify the server certificate.
+		ServerName() string
+	}
+
+	// EditorConfig sets interface for the query editor settings.
+	EditorConfig interface {
+		// Indent returns the indentation width in columns.
+		Indent() int
+		// Tabs reports whether indentation uses tabs instead of spaces.
+		Tabs() bool
 	}
 
 	// DataSource defines an interface for specific data source implementations. All supported
@@ -29,16 +67,42 @@ type (
 	DataSource interface {
 		// Ping checks the underlying data source connection.
 		Ping() error
+		// ServerInfo describes the server and the session of the connection.
+		ServerInfo() (ServerInfo, error)
 		// ListSchemas returns existing schemas in the data source.
 		ListSchemas() ([]string, error)
 		// ListTables returns list of tables for the given schema.
 		ListTables(schema string) ([]string, error)
-		// PreviewTable returns top N records from the selected schema.table.
-		PreviewTable(schema, table string) ([][]*string, error)
+		// ListViews returns list of views for the given schema. Data sources listing views among the tables
+		// return them from both.
+		ListViews(schema string) ([]string, error)
+		// PreviewTable returns a page of records from the selected schema.table, narrowed and ordered by the preview.
+		PreviewTable(schema, table string, preview Preview) ([][]*string, error)
+		// EstimateRows returns the number of rows of the table as estimated by the statistics of the data source,
+		// which is cheap but may be off. It returns -1 when no estimate is available.
+		EstimateRows(schema, table string) (int64, error)
 		// DescribeTable returns tables structural information.
 		DescribeTable(schema, table string) ([][]*string, error)
+		// ListColumns returns the columns of the given schema.table in their definition order.
+		ListColumns(schema, table string) ([]Column, error)
+		// ListSchemaColumns returns the columns of every table and view of the given schema by their name, reading
+		// them at once rather than table by table.
+		ListSchemaColumns(schema string) (map[string][]Column, error)
 		// Query executes the provided SQL query in the selected schema.
 		Query(schema, query string) ([][]*string, error)
+		// Execute runs the statements in a single transaction in the selected schema and returns the number of rows
+		// each statement affected. The transaction is rolled back when a statement fails. In a session transaction
+		// they run behind a savepoint instead, and only their own changes are rolled back.
+		Execute(schema string, statements []Statement) ([]int64, error)
+		// Begin opens a session transaction on a connection of its own. Query, PreviewTable and Execute run
+		// in it until Commit or Rollback ends it, the other methods keep using the connection pool.
+		Begin() error
+		// Commit commits the session transaction.
+		Commit() error
+		// Rollback rolls the session transaction back.
+		Rollback() error
+		// InTransaction reports whether a session transaction is open.
+		InTransaction() bool
 	}
 
 	// DataController defines an interface for high-level data source operations like List, Switch, and Current.
@@ -57,6 +121,70 @@ type (
 
 		// Current returns currently selected data source.
 		Current() DataSource
+
+		// CurrentAlias returns the alias of the currently selected data source.
+		CurrentAlias() string
+	}
+
+	// Column describes a single table column.
+	Column struct {
+		// Name is the column name.
+		Name string
+		// Type is the column type as reported by the data source, e.g. varchar(40).
+		Type string
+		// Nullable reports whether the column accepts NULL values.
+		Nullable bool
+		// PrimaryKey reports whether the column is part of the table's primary key.
+		PrimaryKey bool
+		// Default is the default value expression of the column, or nil if it has none.
+		Default *string
+	}
+
+	// ServerInfo describes the server of a data source and the session dbui uses.
+	ServerInfo struct {
+		// Engine is the name of the database engine, e.g. PostgreSQL.
+		Engine string
+		// Version is the version of the server, e.g. 16.2.
+		Version string
+		// User is the name the session is authenticated as, or empty when the engine has no users.
+		User string
+		// ReadOnly reports whether the session cannot change data.
+		ReadOnly bool
+	}
+
+	// Statement is an SQL statement together with the values of its placeholders.
+	Statement struct {
+		// SQL is the statement text, with placeholders in the syntax of the data source.
+		SQL string
+		// Args are the values of the placeholders in their order; nil is NULL.
+		Args []any
+	}
+
+	// Preview narrows, orders and pages the rows of a table preview. The zero value previews the first rows
+	// in no particular order.
+	Preview struct {
+		// Where is an SQL condition the rows must satisfy, or empty for every row.
+		Where string
+		// OrderBy lists the columns the rows are sorted by, the first one taking precedence.
+		OrderBy []Order
+		// Limit is the number of rows of a page, or 0 for the default of the data source.
+		Limit int
+		// Offset is the number of rows skipped before the page.
+		Offset int
+		// Key lists the primary key columns. Rows without an order of their own are sorted by the key, so that pages
+		// are found by their key values rather than by an offset, which is slow on large tables.
+		Key []string
+		// After holds the key values of the row the page starts after, and Before the ones of the row it ends before.
+		// Both are only used when the rows are sorted by the key.
+		After, Before []*string
+	}
+
+	// Order sorts rows by a column.
+	Order struct {
+		// Column is the column name.
+		Column string
+		// Descending sorts from the largest value to the smallest.
+		Descending bool
 	}
 
 	// Closable is the interface that wraps Close method.
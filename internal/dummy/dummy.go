@@ -4,6 +4,8 @@ package dummy
 import (
 	"errors"
 	"fmt"
+
+	"github.com/kenanbek/dbui/internal"
 )
 
 func sptr(s string) *string {
@@ -19,6 +21,11 @@ func (Dummy) Ping() error {
 	return nil
 }
 
+// ServerInfo exported. The demo data cannot be changed.
+func (Dummy) ServerInfo() (internal.ServerInfo, error) {
+	return internal.ServerInfo{Engine: "Dummy", Version: "demo", ReadOnly: true}, nil
+}
+
 // ListSchemas exported.
 func (Dummy) ListSchemas() ([]string, error) {
 	return []string{
@@ -45,8 +52,17 @@ func (Dummy) ListTables(schema string) ([]string, error) {
 	}, nil
 }
 
+// ListViews exported.
+func (Dummy) ListViews(schema string) ([]string, error) {
+	if schema == "demo_errored" {
+		return nil, errors.New("demo to show an error message")
+	}
+
+	return []string{fmt.Sprintf("demo_%s_view", schema)}, nil
+}
+
 // PreviewTable exported.
-func (Dummy) PreviewTable(_, _ string) ([][]*string, error) {
+func (Dummy) PreviewTable(_, _ string, _ internal.Preview) ([][]*string, error) {
 	return [][]*string{
 		{sptr("Name"), sptr("Surname"), sptr("Department"), sptr("Position")},
 		{sptr("Alex"), sptr("Doe"), sptr("IT"), sptr("Cool")},
@@ -60,6 +76,12 @@ func (Dummy) PreviewTable(_, _ string) ([][]*string, error) {
 	}, nil
 }
 
+// EstimateRows exported.
+func (Dummy) EstimateRows(_, _ string) (int64, error) {
+	rows, err := Dummy{}.PreviewTable("", "", internal.Preview{})
+	return int64(len(rows) - 1), err
+}
+
 // DescribeTable exported.
 func (Dummy) DescribeTable(_, _ string) ([][]*string, error) {
 	return [][]*string{
@@ -71,6 +93,32 @@ func (Dummy) DescribeTable(_, _ string) ([][]*string, error) {
 	}, nil
 }
 
+// ListColumns exported.
+func (Dummy) ListColumns(_, _ string) ([]internal.Column, error) {
+	return []internal.Column{
+		{Name: "Name", Type: "string"},
+		{Name: "Surname", Type: "string"},
+		{Name: "Department", Type: "string"},
+		{Name: "Position", Type: "string"},
+	}, nil
+}
+
+// ListSchemaColumns exported.
+func (Dummy) ListSchemaColumns(schema string) (map[string][]internal.Column, error) {
+	tables, err := Dummy{}.ListTables(schema)
+	if err != nil {
+		return nil, err
+	}
+	views, _ := Dummy{}.ListViews(schema)
+
+	columns := map[string][]internal.Column{}
+	for _, table := range append(tables, views...) {
+		columns[table], _ = Dummy{}.ListColumns(schema, table)
+	}
+
+	return columns, nil
+}
+
 // Query exported.
 func (Dummy) Query(_, _ string) ([][]*string, error) {
 	return [][]*string{
@@ -78,3 +126,28 @@ func (Dummy) Query(_, _ string) ([][]*string, error) {
 		{sptr("val1"), sptr("val2")},
 	}, nil
 }
+
+// Execute exported.
+func (Dummy) Execute(_ string, _ []internal.Statement) ([]int64, error) {
+	return nil, errors.New("the demo data source is read-only")
+}
+
+// Begin exported. The demo data source is read-only, so there is nothing to run in a transaction.
+func (Dummy) Begin() error {
+	return errors.New("the demo data source is read-only")
+}
+
+// Commit exported.
+func (Dummy) Commit() error {
+	return internal.ErrNoTransaction
+}
+
+// Rollback exported.
+func (Dummy) Rollback() error {
+	return internal.ErrNoTransaction
+}
+
+// InTransaction exported.
+func (Dummy) InTransaction() bool {
+	return false
+}
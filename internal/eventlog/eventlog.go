@@ -0,0 +1,100 @@
+// Package eventlog keeps the messages shown to the user during a session, so that they can be read again after the
+// footer moved on.
+package eventlog
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultLimit is the number of entries kept by default; older entries are dropped.
+const DefaultLimit = 500
+
+// Severity tells how important a message is.
+type Severity int
+
+const (
+	// Info reports the outcome of an action.
+	Info Severity = iota
+	// Warning reports an action which could not be carried out as asked.
+	Warning
+	// Error reports a failure, usually of the data source.
+	Error
+)
+
+// String returns the lower case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Entry is a single message.
+type Entry struct {
+	Time     time.Time
+	Severity Severity
+	// Text is the full message, which may span several lines.
+	Text string
+}
+
+// Log is the list of messages of the session, oldest first. Errors are unread until dismissed. It is safe for
+// concurrent use.
+type Log struct {
+	limit int
+
+	mu      sync.Mutex
+	entries []Entry
+	// unread are the errors added since they were last dismissed.
+	unread []Entry
+}
+
+// New returns an empty log keeping the limit most recent entries.
+func New(limit int) *Log {
+	return &Log{limit: limit}
+}
+
+// Add appends a message with the current time and returns its entry.
+func (l *Log) Add(severity Severity, text string) Entry {
+	e := Entry{Time: time.Now(), Severity: severity, Text: text}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, e)
+	if len(l.entries) > l.limit {
+		l.entries = append([]Entry(nil), l.entries[len(l.entries)-l.limit:]...)
+	}
+	if severity == Error {
+		l.unread = append(l.unread, e)
+		if len(l.unread) > l.limit {
+			l.unread = append([]Entry(nil), l.unread[len(l.unread)-l.limit:]...)
+		}
+	}
+
+	return e
+}
+
+// Entries returns a copy of the messages, oldest first.
+func (l *Log) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Entry(nil), l.entries...)
+}
+
+// Unread returns the errors added since they were last dismissed, oldest first.
+func (l *Log) Unread() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Entry(nil), l.unread...)
+}
+
+// Dismiss marks every error read.
+func (l *Log) Dismiss() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.unread = nil
+}
@@ -0,0 +1,42 @@
+package eventlog
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog(t *testing.T) {
+	l := New(3)
+	assert.Empty(t, l.Entries())
+
+	l.Add(Info, "saved")
+	failed := l.Add(Error, "connection refused\nis the server running?")
+	l.Add(Warning, "no rows to copy")
+	assert.Equal(t, Error, failed.Severity)
+	assert.False(t, failed.Time.IsZero())
+
+	entries := l.Entries()
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "saved", entries[0].Text)
+	assert.Equal(t, []Entry{failed}, l.Unread())
+
+	l.Dismiss()
+	assert.Empty(t, l.Unread())
+	assert.Len(t, l.Entries(), 3, "dismissed errors stay in the log")
+
+	for i := 0; i < 4; i++ {
+		l.Add(Error, fmt.Sprint("error ", i))
+	}
+	entries = l.Entries()
+	assert.Len(t, entries, 3, "older entries are dropped")
+	assert.Equal(t, "error 1", entries[0].Text)
+	assert.Len(t, l.Unread(), 3)
+}
+
+func TestSeverity_String(t *testing.T) {
+	assert.Equal(t, "info", Info.String())
+	assert.Equal(t, "warning", Warning.String())
+	assert.Equal(t, "error", Error.String())
+}
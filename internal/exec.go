@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// savepoint is the name of the savepoint statements run behind in an open transaction.
+const savepoint = "dbui_execute"
+
+// ExecuteInTx runs the statements in the transaction and commits it. When a statement fails,
+// the transaction is rolled back and the error is returned.
+func ExecuteInTx(tx *sql.Tx, statements []Statement) ([]int64, error) {
+	affected, err := execute(tx, statements)
+	if err != nil {
+		return nil, errors.Join(err, tx.Rollback())
+	}
+
+	return affected, tx.Commit()
+}
+
+// ExecuteInSavepoint runs the statements in the open transaction the user controls, which stays open. When a
+// statement fails, the changes of the statements before it are rolled back and the error is returned.
+func ExecuteInSavepoint(tx *sql.Tx, statements []Statement) (affected []int64, err error) {
+	err = InSavepoint(tx, func() error {
+		affected, err = execute(tx, statements)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return affected, nil
+}
+
+// InSavepoint runs fn in the open transaction behind a savepoint. When fn fails, the transaction is rolled back to the
+// savepoint, so it stays usable even on databases aborting transactions at the first error, like PostgreSQL.
+func InSavepoint(tx *sql.Tx, fn func() error) error {
+	if _, err := tx.Exec("SAVEPOINT " + savepoint); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		_, rollbackErr := tx.Exec("ROLLBACK TO SAVEPOINT " + savepoint)
+		return errors.Join(err, rollbackErr)
+	}
+
+	_, err := tx.Exec("RELEASE SAVEPOINT " + savepoint)
+	return err
+}
+
+// execute runs the statements one after another and returns the number of rows each affected.
+func execute(tx *sql.Tx, statements []Statement) ([]int64, error) {
+	affected := make([]int64, 0, len(statements))
+	for _, s := range statements {
+		res, err := tx.Exec(s.SQL, s.Args...)
+		if err != nil {
+			return nil, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		affected = append(affected, n)
+	}
+
+	return affected, nil
+}
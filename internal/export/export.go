@@ -0,0 +1,224 @@
+// Package export writes result sets as TSV, CSV, JSON, Markdown tables or SQL INSERT statements.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kenanbek/dbui/internal/sqlgen"
+)
+
+// Format is an output format.
+type Format int
+
+const (
+	// TSV writes tab separated values, the format spreadsheets accept when pasting.
+	TSV Format = iota
+	// CSV writes comma separated values as described by RFC 4180.
+	CSV
+	// JSON writes an array with an object per row.
+	JSON
+	// Markdown writes a Markdown table.
+	Markdown
+	// Insert writes an SQL INSERT statement per row.
+	Insert
+)
+
+// Formats lists every format.
+var Formats = []Format{TSV, CSV, JSON, Markdown, Insert}
+
+// String returns the name of the format, as accepted by ParseFormat.
+func (f Format) String() string {
+	return [...]string{"tsv", "csv", "json", "markdown", "insert"}[f]
+}
+
+// ParseFormat returns the format with the name, ignoring case. "md" and "sql" are accepted as well.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "md":
+		return Markdown, nil
+	case "sql":
+		return Insert, nil
+	}
+	for _, f := range Formats {
+		if strings.EqualFold(f.String(), name) {
+			return f, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown format %q", name)
+}
+
+// Options tune the output of some formats.
+type Options struct {
+	// Header adds the column names as the first line of TSV and CSV output.
+	Header bool
+	// Table is the quoted name of the table INSERT statements write to.
+	Table string
+	// Dialect quotes identifiers and values of INSERT statements.
+	Dialect sqlgen.Dialect
+}
+
+// Write writes the rows in the format. Every row has a value per column; nil values are NULL.
+func Write(w io.Writer, f Format, columns []string, rows [][]*string, opts Options) error {
+	switch f {
+	case TSV:
+		return writeTSV(w, columns, rows, opts)
+	case CSV:
+		return writeCSV(w, columns, rows, opts)
+	case JSON:
+		return writeJSON(w, columns, rows)
+	case Markdown:
+		return writeMarkdown(w, columns, rows)
+	case Insert:
+		return writeInsert(w, columns, rows, opts)
+	default:
+		return fmt.Errorf("unknown format %d", f)
+	}
+}
+
+// String returns the rows in the format.
+func String(f Format, columns []string, rows [][]*string, opts Options) (string, error) {
+	var b bytes.Buffer
+	err := Write(&b, f, columns, rows, opts)
+
+	return b.String(), err
+}
+
+func value(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+func writeTSV(w io.Writer, columns []string, rows [][]*string, opts Options) error {
+	line := func(values []string) error {
+		for i, v := range values {
+			values[i] = tsvReplacer.Replace(v)
+		}
+		_, err := io.WriteString(w, strings.Join(values, "\t")+"\n")
+		return err
+	}
+
+	if opts.Header {
+		if err := line(append([]string(nil), columns...)); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		values := make([]string, len(row))
+		for i, v := range row {
+			values[i] = value(v)
+		}
+		if err := line(values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeCSV(w io.Writer, columns []string, rows [][]*string, opts Options) error {
+	cw := csv.NewWriter(w)
+	if opts.Header {
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		values := make([]string, len(row))
+		for i, v := range row {
+			values[i] = value(v)
+		}
+		if err := cw.Write(values); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writeJSON keeps the column order in the objects, which encoding/json does not do for maps.
+func writeJSON(w io.Writer, columns []string, rows [][]*string) error {
+	var b bytes.Buffer
+	b.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  {")
+		for j, v := range row {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			key, _ := json.Marshal(columns[j])
+			val, _ := json.Marshal(v)
+			b.Write(key)
+			b.WriteString(": ")
+			b.Write(val)
+		}
+		b.WriteString("}")
+	}
+	if len(rows) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+var markdownReplacer = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func writeMarkdown(w io.Writer, columns []string, rows [][]*string) error {
+	line := func(values []string) string {
+		for i, v := range values {
+			values[i] = markdownReplacer.Replace(v)
+		}
+		return "| " + strings.Join(values, " | ") + " |\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(line(append([]string(nil), columns...)))
+	separators := make([]string, len(columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	b.WriteString(line(separators))
+	for _, row := range rows {
+		values := make([]string, len(row))
+		for i, v := range row {
+			if v == nil {
+				values[i] = "NULL"
+			} else {
+				values[i] = *v
+			}
+		}
+		b.WriteString(line(values))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeInsert(w io.Writer, columns []string, rows [][]*string, opts Options) error {
+	table := opts.Table
+	if table == "" {
+		table = opts.Dialect.QuoteIdent("result")
+	}
+	for _, row := range rows {
+		if _, err := io.WriteString(w, opts.Dialect.Insert(table, columns, row)+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal/sqlgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sptr(s string) *string {
+	return &s
+}
+
+var (
+	columns = []string{"id", "note"}
+	rows    = [][]*string{
+		{sptr("1"), sptr("a\tb, \"c\"\nd|e")},
+		{sptr("2"), nil},
+	}
+)
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"tsv": TSV, "CSV": CSV, "json": JSON, "markdown": Markdown, "md": Markdown, "insert": Insert, "sql": Insert} {
+		got, err := ParseFormat(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := ParseFormat("xml")
+	assert.EqualError(t, err, `unknown format "xml"`)
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		format Format
+		opts   Options
+		want   string
+	}{
+		{TSV, Options{}, "1\ta b, \"c\" d|e\n2\t\n"},
+		{TSV, Options{Header: true}, "id\tnote\n1\ta b, \"c\" d|e\n2\t\n"},
+		{CSV, Options{Header: true}, "id,note\n1,\"a\tb, \"\"c\"\"\nd|e\"\n2,\n"},
+		{JSON, Options{}, "[\n  {\"id\": \"1\", \"note\": \"a\\tb, \\\"c\\\"\\nd|e\"},\n  {\"id\": \"2\", \"note\": null}\n]\n"},
+		{Markdown, Options{}, "| id | note |\n| --- | --- |\n| 1 | a\tb, \"c\"<br>d\\|e |\n| 2 | NULL |\n"},
+		{
+			Insert,
+			Options{Table: `"public"."notes"`, Dialect: sqlgen.DialectFor("postgresql")},
+			"INSERT INTO \"public\".\"notes\" (\"id\", \"note\") VALUES ('1', 'a\tb, \"c\"\nd|e');\n" +
+				"INSERT INTO \"public\".\"notes\" (\"id\", \"note\") VALUES ('2', NULL);\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			got, err := String(tt.format, columns, rows, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestString_Empty(t *testing.T) {
+	got, err := String(JSON, columns, nil, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", got)
+}
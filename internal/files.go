@@ -0,0 +1,21 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic replaces the file at path with the data, creating its directory readable by the owner only.
+// The data goes to a temporary file next to it first, which is renamed over the file, so that a crash leaves either
+// the old or the new content but never a part of it.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,23 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dbui", "layouts.json")
+	require.NoError(t, internal.WriteFileAtomic(path, []byte("old"), 0o600))
+	require.NoError(t, internal.WriteFileAtomic(path, []byte("new"), 0o600))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	_, err = os.Stat(path + ".tmp")
+	assert.ErrorIs(t, err, os.ErrNotExist, "the temporary file is renamed")
+}
@@ -0,0 +1,107 @@
+// Package fuzzy ranks strings by how well they match a pattern whose
+// characters appear in order, but not necessarily next to each other.
+package fuzzy
+
+import (
+	"sort"
+	"unicode"
+)
+
+// Scoring of a single matched character.
+const (
+	matchScore       = 16
+	consecutiveBonus = 24
+	boundaryBonus    = 20
+	firstCharBonus   = 8
+	gapPenalty       = 1
+)
+
+// Score reports whether every character of the pattern occurs in the text in order, ignoring case,
+// and how good the match is: consecutive characters, characters at word boundaries and matches
+// close to the start score higher. An empty pattern matches everything with a zero score.
+func Score(pattern, text string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+
+	p, t := lower(pattern), []rune(text)
+	best, found := 0, false
+	// Matching greedily from every occurrence of the first character finds e.g. the word start in "dept_emp" for "emp".
+	for start := range t {
+		if unicode.ToLower(t[start]) != p[0] {
+			continue
+		}
+		if score, ok := scoreFrom(p, t, start); ok && (!found || score > best) {
+			best, found = score, true
+		}
+	}
+
+	return best, found
+}
+
+// scoreFrom matches the lower case pattern greedily from the start index of the text.
+func scoreFrom(p, t []rune, start int) (int, bool) {
+	score, last, next := -start*gapPenalty, -1, 0
+	for i := start; i < len(t) && next < len(p); i++ {
+		if unicode.ToLower(t[i]) != p[next] {
+			continue
+		}
+		score += matchScore
+		switch {
+		case i == 0:
+			score += firstCharBonus + boundaryBonus
+		case last == i-1:
+			score += consecutiveBonus
+		case isBoundary(t[i-1], t[i]):
+			score += boundaryBonus
+		}
+		if last >= 0 {
+			score -= (i - last - 1) * gapPenalty
+		}
+		last = i
+		next++
+	}
+
+	return score, next == len(p)
+}
+
+func lower(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// isBoundary reports whether r starts a word after prev, e.g. the b in "a_b", "a b", "a.b" or "aB".
+func isBoundary(prev, r rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(r)
+}
+
+// Filter returns the indexes of the n texts matching the pattern, best matches first.
+// Texts with equal scores keep their order.
+func Filter(pattern string, n int, text func(i int) string) []int {
+	type match struct {
+		index, score int
+	}
+
+	matches := make([]match, 0, n)
+	for i := 0; i < n; i++ {
+		if score, ok := Score(pattern, text(i)); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+
+	return indexes
+}
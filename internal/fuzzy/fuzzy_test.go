@@ -0,0 +1,50 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		match         bool
+	}{
+		{"", "anything", true},
+		{"sel", "SELECT 1", true},
+		{"slct", "select", true},
+		{"tcel", "select", false},
+		{"émp", "Émployees", true},
+		{"select", "sel", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.text, func(t *testing.T) {
+			_, ok := Score(tt.pattern, tt.text)
+			assert.Equal(t, tt.match, ok)
+		})
+	}
+}
+
+func TestScore_Ranking(t *testing.T) {
+	score := func(pattern, text string) int {
+		s, ok := Score(pattern, text)
+		assert.True(t, ok, "%q should match %q", pattern, text)
+		return s
+	}
+
+	assert.Greater(t, score("emp", "employees"), score("emp", "dept_emp_latest"), "a prefix beats a later match")
+	assert.Greater(t, score("emp", "dept_emp"), score("emp", "temporary"), "a word start beats the middle of a word")
+	assert.Greater(t, score("ds", "dept_salary"), score("ds", "dollars"), "word starts beat scattered characters")
+	assert.Greater(t, score("ui", "userId"), score("ui", "quit"), "camel case humps are word starts")
+}
+
+func TestFilter(t *testing.T) {
+	texts := []string{"select * from salaries", "show tables", "select * from employees", "delete from t"}
+	at := func(i int) string { return texts[i] }
+
+	assert.Equal(t, []int{0, 1, 2, 3}, Filter("", len(texts), at), "an empty pattern keeps the order")
+	assert.Equal(t, []int{2}, Filter("employ", len(texts), at))
+	assert.Equal(t, []int{1, 0, 2}, Filter("st", len(texts), at))
+}
@@ -0,0 +1,171 @@
+// Package history keeps the queries executed by the user in a JSON lines file, one entry per query,
+// so that they survive restarts and can be recalled and searched later.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/config"
+)
+
+// DefaultLimit is the number of entries kept by default; older entries are dropped.
+const DefaultLimit = 1000
+
+// Entry is a single executed query.
+type Entry struct {
+	Alias    string        `json:"alias"`
+	Schema   string        `json:"schema"`
+	Query    string        `json:"query"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+	// Rows is the number of rows the query returned.
+	Rows int `json:"rows"`
+	// Error is the error message of a failed query, empty on success.
+	Error string `json:"error,omitempty"`
+}
+
+// Failed reports whether the query failed.
+func (e Entry) Failed() bool {
+	return e.Error != ""
+}
+
+// History is the list of executed queries, oldest first. It is safe for concurrent use.
+type History struct {
+	path  string
+	limit int
+
+	mu      sync.Mutex
+	entries []Entry
+	// lines is the number of entries in the file, which grows beyond the limit until it is compacted.
+	lines int
+}
+
+// DefaultFile returns the path of the history file, history.jsonl in the data directory.
+func DefaultFile() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
+// Open loads the history stored in the file at path, keeping the limit most recent entries.
+// A missing file is an empty history, and lines which cannot be parsed are skipped.
+// An empty path keeps the history in memory only.
+func Open(path string, limit int) (*History, error) {
+	h := &History{path: path, limit: limit}
+	if path == "" {
+		return h, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseOrLog(f)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		h.lines++
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil && e.Query != "" {
+			h.entries = append(h.entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	h.trim()
+
+	return h, nil
+}
+
+func (h *History) trim() {
+	if h.limit > 0 && len(h.entries) > h.limit {
+		h.entries = append([]Entry(nil), h.entries[len(h.entries)-h.limit:]...)
+	}
+}
+
+// Add appends the entry to the history and its file. Once the file holds twice the limit of entries,
+// it is rewritten with the entries kept in memory.
+func (h *History) Add(e Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, e)
+	h.trim()
+	if h.path == "" {
+		return nil
+	}
+
+	if h.limit > 0 && h.lines+1 > 2*h.limit {
+		return h.rewrite()
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return err
+	}
+	// Queries may contain sensitive data, so the file is readable by its owner only.
+	f, err := os.OpenFile(h.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	h.lines++
+
+	return f.Close()
+}
+
+// rewrite replaces the file with the entries kept in memory.
+func (h *History) rewrite() error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, e := range h.entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	if err := internal.WriteFileAtomic(h.path, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+
+	h.lines = len(h.entries)
+	return nil
+}
+
+// Entries returns the entries of the data source with the alias, or all entries for an empty alias, newest first.
+func (h *History) Entries(alias string) []Entry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make([]Entry, 0, len(h.entries))
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if alias == "" || h.entries[i].Alias == alias {
+			result = append(result, h.entries[i])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultFile(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	path, err := DefaultFile()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/data", "dbui", "history.jsonl"), path)
+}
+
+func TestHistory_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dbui", "history.jsonl")
+	h, err := Open(path, DefaultLimit)
+	require.NoError(t, err)
+	assert.Empty(t, h.Entries(""), "a missing file is an empty history")
+
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, h.Add(Entry{Alias: "pg", Schema: "world", Query: "select 1", Time: at, Duration: time.Millisecond, Rows: 1}))
+	require.NoError(t, h.Add(Entry{Alias: "my", Schema: "employees", Query: "select x", Time: at, Error: "unknown column"}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	reopened, err := Open(path, DefaultLimit)
+	require.NoError(t, err)
+	entries := reopened.Entries("")
+	require.Len(t, entries, 2)
+	assert.Equal(t, "select x", entries[0].Query, "newest first")
+	assert.True(t, entries[0].Failed())
+	assert.Equal(t, Entry{Alias: "pg", Schema: "world", Query: "select 1", Time: at, Duration: time.Millisecond, Rows: 1}, entries[1])
+
+	pg := reopened.Entries("pg")
+	require.Len(t, pg, 1)
+	assert.Equal(t, "select 1", pg[0].Query)
+}
+
+func TestHistory_SkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	content := `{"alias":"pg","query":"select 1"}` + "\n" + "not json\n" + `{"alias":"pg","query":"select 2"}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	h, err := Open(path, DefaultLimit)
+	require.NoError(t, err)
+	assert.Len(t, h.Entries(""), 2)
+}
+
+func TestHistory_Limit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	h, err := Open(path, 3)
+	require.NoError(t, err)
+
+	for _, q := range []string{"q1", "q2", "q3", "q4", "q5", "q6", "q7"} {
+		require.NoError(t, h.Add(Entry{Query: q}))
+	}
+	var queries []string
+	for _, e := range h.Entries("") {
+		queries = append(queries, e.Query)
+	}
+	assert.Equal(t, []string{"q7", "q6", "q5"}, queries)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, strings.Count(string(content), "\n"), 6, "the file is compacted once it holds twice the limit")
+
+	reopened, err := Open(path, 3)
+	require.NoError(t, err)
+	assert.Len(t, reopened.Entries(""), 3)
+}
+
+func TestHistory_InMemory(t *testing.T) {
+	h, err := Open("", DefaultLimit)
+	require.NoError(t, err)
+	require.NoError(t, h.Add(Entry{Query: "select 1"}))
+	assert.Len(t, h.Entries(""), 1)
+}
@@ -0,0 +1,164 @@
+// Package layout remembers how the columns of table previews are shown, hidden, ordered, frozen and sized,
+// per table, in a JSON file so that the settings survive restarts.
+package layout
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/config"
+)
+
+// Layout tells how the columns of a result are shown. The zero value shows every column in the result order,
+// as wide as its content, without freezing any.
+type Layout struct {
+	// Order lists column names in the order they are shown. Columns it does not name follow in the result order.
+	Order []string `json:"order,omitempty"`
+	// Hidden lists the names of the columns not shown.
+	Hidden []string `json:"hidden,omitempty"`
+	// Frozen is the number of leading shown columns kept in view while scrolling sideways.
+	Frozen int `json:"frozen,omitempty"`
+	// MaxWidth caps the width of every column, cutting longer values with an ellipsis. 0 fits columns to their content.
+	MaxWidth int `json:"maxWidth,omitempty"`
+}
+
+// Columns returns the indexes of the shown columns among the names, in the order they are shown.
+func (l Layout) Columns(names []string) []int {
+	return slices.DeleteFunc(l.order(names), func(i int) bool { return slices.Contains(l.Hidden, names[i]) })
+}
+
+// order returns the indexes of every column among the names, hidden or not, in the order they are shown.
+func (l Layout) order(names []string) []int {
+	var columns []int
+	for _, name := range l.Order {
+		if i := slices.Index(names, name); i >= 0 && !slices.Contains(columns, i) {
+			columns = append(columns, i)
+		}
+	}
+	for i := range names {
+		if !slices.Contains(columns, i) {
+			columns = append(columns, i)
+		}
+	}
+
+	return columns
+}
+
+// Hide hides the column, or shows it again.
+func (l *Layout) Hide(name string, hidden bool) {
+	l.Hidden = slices.DeleteFunc(l.Hidden, func(h string) bool { return h == name })
+	if hidden {
+		l.Hidden = append(l.Hidden, name)
+	}
+}
+
+// Move swaps the column with the next shown one to the right, or with the previous one to the left when step is -1.
+// It reports whether the column moved; the first and the last shown columns cannot move further out.
+func (l *Layout) Move(names []string, name string, step int) bool {
+	order := l.order(names)
+	from := slices.IndexFunc(order, func(i int) bool { return names[i] == name })
+	if from < 0 {
+		return false
+	}
+
+	// Hidden columns in between keep their place.
+	to := from + step
+	for to >= 0 && to < len(order) && slices.Contains(l.Hidden, names[order[to]]) {
+		to += step
+	}
+	if to < 0 || to >= len(order) {
+		return false
+	}
+	order[from], order[to] = order[to], order[from]
+
+	l.Order = make([]string, len(order))
+	for i, column := range order {
+		l.Order[i] = names[column]
+	}
+
+	return true
+}
+
+// clone returns a copy of the layout which shares no slices with it.
+func (l Layout) clone() Layout {
+	l.Order, l.Hidden = slices.Clone(l.Order), slices.Clone(l.Hidden)
+	return l
+}
+
+// Store keeps the layouts of tables in a JSON file. It is safe for concurrent use.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	layouts map[string]Layout
+}
+
+// Key returns the key the layout of a table is stored by.
+func Key(alias, schema, table string) string {
+	return alias + "/" + schema + "/" + table
+}
+
+// DefaultFile returns the path of the layouts file, layouts.json in the data directory.
+func DefaultFile() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "layouts.json"), nil
+}
+
+// Open loads the layouts stored in the file at path. A missing file holds no layouts.
+// An empty path keeps the layouts in memory only.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, layouts: map[string]Layout{}}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.layouts); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Get returns the layout stored by the key, if any.
+func (s *Store) Get(key string) (Layout, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.layouts[key]
+	return l.clone(), ok
+}
+
+// Set stores the layout by the key and writes every layout to the file.
+func (s *Store) Set(key string, l Layout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.layouts[key] = l.clone()
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.layouts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return internal.WriteFileAtomic(s.path, append(data, '\n'), 0o600)
+}
@@ -0,0 +1,60 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayout_Columns(t *testing.T) {
+	names := []string{"id", "name", "email", "created"}
+
+	assert.Equal(t, []int{0, 1, 2, 3}, Layout{}.Columns(names))
+	assert.Equal(t, []int{2, 0, 3}, Layout{Order: []string{"email", "gone", "id"}, Hidden: []string{"name"}}.Columns(names),
+		"unknown names are skipped, and unnamed columns follow")
+}
+
+func TestLayout_Move(t *testing.T) {
+	names := []string{"id", "name", "email", "created"}
+	l := Layout{Hidden: []string{"name"}}
+
+	assert.True(t, l.Move(names, "email", -1))
+	assert.Equal(t, []int{2, 0, 3}, l.Columns(names))
+	assert.Equal(t, []string{"email", "name", "id", "created"}, l.Order, "hidden columns keep their place")
+
+	assert.False(t, l.Move(names, "email", -1), "the first column cannot move left")
+	assert.False(t, l.Move(names, "created", 1))
+	assert.False(t, l.Move(names, "gone", 1))
+
+	l.Hide("name", false)
+	assert.Equal(t, []int{2, 1, 0, 3}, l.Columns(names))
+}
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dbui", "layouts.json")
+	s, err := Open(path)
+	require.NoError(t, err)
+	_, ok := s.Get(Key("pg", "public", "users"))
+	assert.False(t, ok)
+
+	l := Layout{Hidden: []string{"password"}, Frozen: 2, MaxWidth: 24}
+	require.NoError(t, s.Set(Key("pg", "public", "users"), l))
+	l.Hide("password", false)
+
+	s, err = Open(path)
+	require.NoError(t, err)
+	stored, ok := s.Get(Key("pg", "public", "users"))
+	assert.True(t, ok)
+	assert.Equal(t, Layout{Hidden: []string{"password"}, Frozen: 2, MaxWidth: 24}, stored)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = Open(path)
+	assert.Error(t, err)
+}
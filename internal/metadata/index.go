@@ -0,0 +1,150 @@
+package metadata
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kenanbek/dbui/internal"
+)
+
+// Kind tells what an indexed object is.
+type Kind int
+
+const (
+	// Table objects are tables.
+	Table Kind = iota
+	// View objects are views.
+	View
+	// Column objects are columns of tables and views.
+	Column
+)
+
+// String returns the name of the kind, e.g. "table".
+func (k Kind) String() string {
+	switch k {
+	case View:
+		return "view"
+	case Column:
+		return "column"
+	default:
+		return "table"
+	}
+}
+
+// Object is a table, view or column of the index.
+type Object struct {
+	Kind   Kind
+	Schema string
+	// Table is the name of the table or view, or the one the column belongs to.
+	Table string
+	// Column is the name of Column objects.
+	Column string
+}
+
+// Name returns the qualified name of the object, e.g. "employees.salaries.amount".
+func (o Object) Name() string {
+	parts := []string{o.Schema, o.Table}
+	if o.Kind == Column {
+		parts = append(parts, o.Column)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Index returns the tables, views and columns of the schemas indexed so far, and whether every schema is indexed.
+// The first call starts indexing the data source in the background, one schema after another, reporting each
+// indexed schema through the loaded callback. The tables and columns read on the way are cached as well.
+func (c *Cache) Index() ([]Object, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var objects []Object
+	for _, schema := range c.schemas {
+		objects = append(objects, c.index[schema]...)
+	}
+	if !c.indexed {
+		c.buildIndex()
+	}
+
+	return objects, c.indexed
+}
+
+// buildIndex indexes every schema in the background unless it is already being indexed.
+func (c *Cache) buildIndex() {
+	if c.pending[indexKey{}] {
+		return
+	}
+	c.pending[indexKey{}] = true
+	gen := c.gen
+
+	go func() {
+		schemas, err := c.ds.ListSchemas()
+		if err != nil || schemas == nil {
+			schemas = []string{}
+		}
+		if !c.store(gen, func() { c.schemas = schemas }) {
+			return
+		}
+
+		for _, schema := range schemas {
+			tables, columns, objects := c.indexSchema(schema)
+			stored := c.store(gen, func() {
+				if _, ok := c.tables[schema]; !ok {
+					c.tables[schema] = tables
+				}
+				for key, cols := range columns {
+					if _, ok := c.columns[key]; !ok {
+						c.columns[key] = cols
+					}
+				}
+				c.index[schema] = objects
+			})
+			if !stored {
+				return
+			}
+		}
+
+		c.store(gen, func() {
+			c.indexed = true
+			delete(c.pending, indexKey{})
+		})
+	}()
+}
+
+// indexSchema reads the tables, views and their columns of the schema. Tables and views come first, so that they
+// rank before columns of equal score. Failures leave out what could not be read.
+func (c *Cache) indexSchema(schema string) ([]string, map[tableKey][]internal.Column, []Object) {
+	tables, err := c.ds.ListTables(schema)
+	if err != nil {
+		tables = nil
+	}
+	views, err := c.ds.ListViews(schema)
+	if err != nil {
+		views = nil
+	}
+
+	var objects []Object
+	for _, table := range tables {
+		if !slices.Contains(views, table) {
+			objects = append(objects, Object{Kind: Table, Schema: schema, Table: table})
+		}
+	}
+	for _, view := range views {
+		objects = append(objects, Object{Kind: View, Schema: schema, Table: view})
+	}
+
+	// The columns of the whole schema are read at once, as a query per table adds up on schemas with many tables.
+	all, err := c.ds.ListSchemaColumns(schema)
+	if err != nil {
+		all = nil
+	}
+	columns := map[tableKey][]internal.Column{}
+	for _, relation := range slices.Clone(objects) {
+		cols := all[relation.Table]
+		columns[tableKey{schema, relation.Table}] = cols
+		for _, col := range cols {
+			objects = append(objects, Object{Kind: Column, Schema: schema, Table: relation.Table, Column: col.Name})
+		}
+	}
+
+	return tables, columns, objects
+}
@@ -0,0 +1,157 @@
+// Package metadata caches the schemas, tables and columns of a data source.
+// Lookups never block on the database: a missing entry is loaded in the
+// background, and the cache reports through a callback when it arrives.
+package metadata
+
+import (
+	"sync"
+
+	"github.com/kenanbek/dbui/internal"
+)
+
+type (
+	schemasKey struct{}
+	indexKey   struct{}
+	tablesKey  string
+	tableKey   struct {
+		schema, table string
+	}
+)
+
+// Cache keeps the metadata of a single data source in memory until it is invalidated.
+type Cache struct {
+	ds     internal.DataSource
+	loaded func()
+
+	mu      sync.Mutex
+	gen     int
+	schemas []string
+	tables  map[string][]string
+	columns map[tableKey][]internal.Column
+	pending map[any]bool
+	// index holds the objects of the indexed schemas, and indexed tells whether every schema is.
+	index   map[string][]Object
+	indexed bool
+}
+
+// New returns an empty cache for the data source. The loaded callback, if not nil, is called from
+// a background goroutine whenever entries requested earlier become available.
+func New(ds internal.DataSource, loaded func()) *Cache {
+	c := &Cache{ds: ds, loaded: loaded}
+	c.reset()
+
+	return c
+}
+
+func (c *Cache) reset() {
+	c.schemas = nil
+	c.tables = map[string][]string{}
+	c.columns = map[tableKey][]internal.Column{}
+	c.pending = map[any]bool{}
+	c.index = map[string][]Object{}
+	c.indexed = false
+}
+
+// Invalidate drops every cached entry, e.g. after the schema of the data source changed.
+// Loads still running are discarded.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.reset()
+}
+
+// Schemas returns the cached schemas. When they are not cached yet, it returns false and starts loading them.
+func (c *Cache) Schemas() ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.schemas != nil {
+		return c.schemas, true
+	}
+	c.load(schemasKey{}, func() func() {
+		schemas, err := c.ds.ListSchemas()
+		if err != nil || schemas == nil {
+			schemas = []string{}
+		}
+		return func() { c.schemas = schemas }
+	})
+
+	return nil, false
+}
+
+// Tables returns the cached tables of the schema. When they are not cached yet, it returns false and starts loading them.
+func (c *Cache) Tables(schema string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tables, ok := c.tables[schema]; ok {
+		return tables, true
+	}
+	c.load(tablesKey(schema), func() func() {
+		tables, err := c.ds.ListTables(schema)
+		if err != nil {
+			tables = nil
+		}
+		return func() { c.tables[schema] = tables }
+	})
+
+	return nil, false
+}
+
+// Columns returns the cached columns of the table. When they are not cached yet, it returns false and starts loading them.
+func (c *Cache) Columns(schema, table string) ([]internal.Column, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := tableKey{schema, table}
+	if columns, ok := c.columns[key]; ok {
+		return columns, true
+	}
+	c.load(key, func() func() {
+		columns, err := c.ds.ListColumns(schema, table)
+		if err != nil {
+			columns = nil
+		}
+		return func() { c.columns[key] = columns }
+	})
+
+	return nil, false
+}
+
+// load runs fetch in the background unless the key is already being loaded. Failed loads are cached as
+// empty results, so a broken table is not queried again on every key stroke; Invalidate retries them.
+// fetch runs without the lock and returns the function storing its result, which runs with the lock held.
+func (c *Cache) load(key any, fetch func() func()) {
+	if c.pending[key] {
+		return
+	}
+	c.pending[key] = true
+	gen := c.gen
+
+	go func() {
+		store := fetch()
+		c.store(gen, func() {
+			store()
+			delete(c.pending, key)
+		})
+	}()
+}
+
+// store runs f with the lock held and reports the loaded entries, unless the cache was invalidated since the load
+// started in generation gen. It reports whether f ran.
+func (c *Cache) store(gen int, f func()) bool {
+	c.mu.Lock()
+	stale := gen != c.gen
+	if !stale {
+		f()
+	}
+	c.mu.Unlock()
+
+	if !stale && c.loaded != nil {
+		c.loaded()
+	}
+
+	return !stale
+}
@@ -0,0 +1,162 @@
+package metadata
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDataSource serves fixed metadata and counts the calls per method.
+type fakeDataSource struct {
+	internal.DataSource
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *fakeDataSource) count(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[method]++
+}
+
+func (f *fakeDataSource) ListSchemas() ([]string, error) {
+	f.count("ListSchemas")
+	return []string{"employees", "world"}, nil
+}
+
+func (f *fakeDataSource) ListTables(schema string) ([]string, error) {
+	f.count("ListTables")
+	if schema == "broken" {
+		return nil, errors.New("access denied")
+	}
+	return []string{"departments", "salaries"}, nil
+}
+
+func (f *fakeDataSource) ListViews(schema string) ([]string, error) {
+	f.count("ListViews")
+	if schema == "world" {
+		return []string{"salaries"}, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeDataSource) ListColumns(_, table string) ([]internal.Column, error) {
+	f.count("ListColumns")
+	return []internal.Column{{Name: table + "_id", Type: "int", PrimaryKey: true}}, nil
+}
+
+func (f *fakeDataSource) ListSchemaColumns(_ string) (map[string][]internal.Column, error) {
+	f.count("ListSchemaColumns")
+	columns := map[string][]internal.Column{}
+	for _, table := range []string{"departments", "salaries"} {
+		columns[table] = []internal.Column{{Name: table + "_id", Type: "int", PrimaryKey: true}}
+	}
+	return columns, nil
+}
+
+func newTestCache(t *testing.T) (*Cache, *fakeDataSource, chan struct{}) {
+	ds := &fakeDataSource{calls: map[string]int{}}
+	loaded := make(chan struct{}, 10)
+	return New(ds, func() { loaded <- struct{}{} }), ds, loaded
+}
+
+func wait(t *testing.T, loaded chan struct{}) {
+	select {
+	case <-loaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("metadata was not loaded")
+	}
+}
+
+func TestCache(t *testing.T) {
+	c, ds, loaded := newTestCache(t)
+
+	_, ok := c.Schemas()
+	assert.False(t, ok, "the first lookup starts loading")
+	_, ok = c.Schemas()
+	assert.False(t, ok)
+	wait(t, loaded)
+
+	schemas, ok := c.Schemas()
+	require.True(t, ok)
+	assert.Equal(t, []string{"employees", "world"}, schemas)
+
+	_, ok = c.Tables("employees")
+	assert.False(t, ok)
+	_, ok = c.Columns("employees", "salaries")
+	assert.False(t, ok)
+	wait(t, loaded)
+	wait(t, loaded)
+
+	tables, ok := c.Tables("employees")
+	require.True(t, ok)
+	assert.Equal(t, []string{"departments", "salaries"}, tables)
+	columns, ok := c.Columns("employees", "salaries")
+	require.True(t, ok)
+	assert.Equal(t, "salaries_id", columns[0].Name)
+
+	assert.Equal(t, map[string]int{"ListSchemas": 1, "ListTables": 1, "ListColumns": 1}, ds.calls, "entries are loaded once")
+
+	c.Invalidate()
+	_, ok = c.Tables("employees")
+	assert.False(t, ok)
+	wait(t, loaded)
+	assert.Equal(t, 2, ds.calls["ListTables"])
+}
+
+func TestCache_Errors(t *testing.T) {
+	c, ds, loaded := newTestCache(t)
+
+	_, ok := c.Tables("broken")
+	assert.False(t, ok)
+	wait(t, loaded)
+
+	tables, ok := c.Tables("broken")
+	assert.True(t, ok, "failures are cached as empty results")
+	assert.Empty(t, tables)
+	assert.Equal(t, 1, ds.calls["ListTables"])
+}
+
+func TestCache_Index(t *testing.T) {
+	c, ds, loaded := newTestCache(t)
+
+	objects, ok := c.Index()
+	assert.False(t, ok, "the first lookup starts indexing")
+	assert.Empty(t, objects)
+	// The schemas are reported first, then every indexed schema.
+	wait(t, loaded)
+	wait(t, loaded)
+	wait(t, loaded)
+
+	objects, ok = c.Index()
+	require.True(t, ok)
+	names := make([]string, len(objects))
+	for i, o := range objects {
+		names[i] = o.Kind.String() + " " + o.Name()
+	}
+	assert.Equal(t, []string{
+		"table employees.departments", "table employees.salaries",
+		"column employees.departments.departments_id", "column employees.salaries.salaries_id",
+		"table world.departments", "view world.salaries",
+		"column world.departments.departments_id", "column world.salaries.salaries_id",
+	}, names)
+
+	tables, ok := c.Tables("world")
+	assert.True(t, ok, "indexing caches the tables")
+	assert.Equal(t, []string{"departments", "salaries"}, tables)
+	_, ok = c.Columns("employees", "salaries")
+	assert.True(t, ok, "indexing caches the columns")
+	assert.Equal(t, 2, ds.calls["ListTables"])
+	assert.Equal(t, 2, ds.calls["ListSchemaColumns"], "the columns are read once per schema")
+	assert.Zero(t, ds.calls["ListColumns"])
+
+	c.Invalidate()
+	_, ok = c.Index()
+	assert.False(t, ok)
+}
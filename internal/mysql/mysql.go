@@ -3,25 +3,40 @@ package mysql
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"net"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql" // import mysql driver.
+	"github.com/go-sql-driver/mysql"
 	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/sqlgen"
 )
 
+var dialect = sqlgen.DialectFor("mysql")
+
 // DataSource implements internal.DataSource interface for MySQL storage.
 type DataSource struct {
-	db *sql.DB
+	db      *sql.DB
+	session internal.Session
 }
 
-func (d *DataSource) query(schema, query string) (data [][]*string, err error) {
+// query runs the query in the session transaction, or else in a transaction of its own.
+func (d *DataSource) query(schema, query string) ([][]*string, error) {
+	if tx := d.session.Tx(); tx != nil {
+		return queryIn(tx, schema, query)
+	}
+
 	tx, err := d.db.Begin()
 	if err != nil {
-		return
+		return nil, err
 	}
 	defer internal.CommitOrLog(tx)
 
+	return queryIn(tx, schema, query)
+}
+
+func queryIn(tx *sql.Tx, schema, query string) (data [][]*string, err error) {
 	res, err := tx.Query(fmt.Sprintf("USE %s", schema))
 	if err != nil {
 		return
@@ -68,7 +83,13 @@ func (d *DataSource) query(schema, query string) (data [][]*string, err error) {
 // New configures a new connection to the MySQL data source
 // and returns an instance of it which implements internal.DataSource interface.
 func New(dsn string) (*DataSource, error) {
-	db, err := sql.Open("mysql", dsn)
+	return NewWithTLS(dsn, nil)
+}
+
+// NewWithTLS works as New but secures the connection with the provided TLS settings.
+// A nil tlsConfig keeps the TLS behaviour defined by the DSN itself.
+func NewWithTLS(dsn string, tlsConfig internal.TLSConfig) (*DataSource, error) {
+	db, err := open(dsn, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -80,11 +101,55 @@ func New(dsn string) (*DataSource, error) {
 	return &DataSource{db: db}, nil
 }
 
+func open(dsn string, tlsConfig internal.TLSConfig) (*sql.DB, error) {
+	if tlsConfig == nil {
+		return sql.Open("mysql", dsn)
+	}
+
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.TLS, err = internal.NewTLSConfig(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	// ParseDSN only fills in the server name for TLS configs named in the DSN itself.
+	if cfg.TLS.ServerName == "" && !cfg.TLS.InsecureSkipVerify {
+		if host, _, err := net.SplitHostPort(cfg.Addr); err == nil {
+			cfg.TLS.ServerName = host
+		}
+	}
+
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(connector), nil
+}
+
+// ValidateDSN reports whether dsn is a well-formed MySQL data source name.
+func ValidateDSN(dsn string) error {
+	_, err := mysql.ParseDSN(dsn)
+	return err
+}
+
 // Ping exported.
 func (d *DataSource) Ping() error {
 	return d.db.Ping()
 }
 
+// ServerInfo exported. The session is read-only when the server or the session transactions are.
+func (d *DataSource) ServerInfo() (info internal.ServerInfo, err error) {
+	info.Engine = "MySQL"
+	err = d.db.QueryRow("SELECT VERSION(), CURRENT_USER(), @@global.read_only OR @@session.transaction_read_only").
+		Scan(&info.Version, &info.User, &info.ReadOnly)
+
+	return
+}
+
 // ListSchemas exported.
 func (d *DataSource) ListSchemas() (schemas []string, err error) {
 	res, err := d.db.Query("SHOW DATABASES")
@@ -137,9 +202,62 @@ func (d *DataSource) ListTables(schema string) (tables []string, err error) {
 	return
 }
 
+// Execute exported.
+func (d *DataSource) Execute(schema string, statements []internal.Statement) ([]int64, error) {
+	if tx := d.session.Tx(); tx != nil {
+		if _, err := tx.Exec(fmt.Sprintf("USE %s", schema)); err != nil {
+			return nil, err
+		}
+		return internal.ExecuteInSavepoint(tx, statements)
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.Exec(fmt.Sprintf("USE %s", schema)); err != nil {
+		return nil, errors.Join(err, tx.Rollback())
+	}
+
+	return internal.ExecuteInTx(tx, statements)
+}
+
+// ListViews exported. SHOW TABLES lists views too, so they are part of ListTables as well.
+func (d *DataSource) ListViews(schema string) (views []string, err error) {
+	res, err := d.db.Query("SELECT table_name FROM information_schema.views WHERE table_schema = ? ORDER BY table_name", schema)
+	if err != nil {
+		return
+	}
+	defer internal.CloseOrLog(res)
+
+	views = []string{}
+	for res.Next() {
+		var view string
+		if err = res.Scan(&view); err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	return views, res.Err()
+}
+
 // PreviewTable exported.
-func (d *DataSource) PreviewTable(schema string, table string) ([][]*string, error) {
-	return d.query(schema, fmt.Sprintf("SELECT * FROM %s LIMIT 50", table))
+func (d *DataSource) PreviewTable(schema string, table string, preview internal.Preview) ([][]*string, error) {
+	return d.query(schema, dialect.Select(dialect.QuoteIdent(table), preview, 50))
+}
+
+// EstimateRows exported. InnoDB only samples the table, so the estimate may be off by a large margin.
+func (d *DataSource) EstimateRows(schema, table string) (int64, error) {
+	var rows sql.NullInt64
+	err := d.db.QueryRow("SELECT table_rows FROM information_schema.tables WHERE table_schema = ? AND table_name = ?",
+		schema, table).Scan(&rows)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && !rows.Valid {
+		return -1, nil
+	}
+
+	return rows.Int64, err
 }
 
 // DescribeTable exported.
@@ -147,7 +265,69 @@ func (d *DataSource) DescribeTable(schema string, table string) ([][]*string, er
 	return d.query(schema, fmt.Sprintf("DESCRIBE %s", table))
 }
 
+// ListColumns exported.
+func (d *DataSource) ListColumns(schema, table string) ([]internal.Column, error) {
+	columns, err := d.listColumns(schema, table)
+	if err != nil {
+		return nil, err
+	}
+	if columns[table] == nil {
+		return []internal.Column{}, nil
+	}
+
+	return columns[table], nil
+}
+
+// ListSchemaColumns exported.
+func (d *DataSource) ListSchemaColumns(schema string) (map[string][]internal.Column, error) {
+	return d.listColumns(schema, "")
+}
+
+// listColumns reads the columns of the table, or of every table and view when table is empty, by table name.
+func (d *DataSource) listColumns(schema, table string) (columns map[string][]internal.Column, err error) {
+	res, err := d.db.Query(
+		"SELECT table_name, column_name, column_type, is_nullable = 'YES', column_key = 'PRI', column_default "+
+			"FROM information_schema.columns WHERE table_schema = ? AND (? = '' OR table_name = ?) "+
+			"ORDER BY table_name, ordinal_position", schema, table, table)
+	if err != nil {
+		return
+	}
+	defer internal.CloseOrLog(res)
+
+	columns = map[string][]internal.Column{}
+	for res.Next() {
+		var name string
+		var c internal.Column
+		if err = res.Scan(&name, &c.Name, &c.Type, &c.Nullable, &c.PrimaryKey, &c.Default); err != nil {
+			return nil, err
+		}
+		columns[name] = append(columns[name], c)
+	}
+
+	return columns, res.Err()
+}
+
 // Query exported.
 func (d *DataSource) Query(schema, query string) ([][]*string, error) {
 	return d.query(schema, query)
 }
+
+// Begin exported.
+func (d *DataSource) Begin() error {
+	return d.session.Begin(d.db)
+}
+
+// Commit exported.
+func (d *DataSource) Commit() error {
+	return d.session.Commit()
+}
+
+// Rollback exported.
+func (d *DataSource) Rollback() error {
+	return d.session.Rollback()
+}
+
+// InTransaction exported.
+func (d *DataSource) InTransaction() bool {
+	return d.session.Active()
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kenanbek/dbui/internal"
 	"github.com/kenanbek/dbui/internal/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
@@ -119,10 +120,35 @@ func TestDataSource_ListTables(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDataSource_ListViews(t *testing.T) {
+	views, err := db.ListViews("employees")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"current_dept_emp", "dept_emp_latest_date", "v_full_departments", "v_full_employees"}, views)
+}
+
+func TestDataSource_ServerInfo(t *testing.T) {
+	info, err := db.ServerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "MySQL", info.Engine)
+	assert.NotEmpty(t, info.Version)
+	assert.NotEmpty(t, info.User)
+	assert.False(t, info.ReadOnly)
+}
+
+func TestDataSource_EstimateRows(t *testing.T) {
+	rows, err := db.EstimateRows("employees", "departments")
+	assert.NoError(t, err)
+	assert.Positive(t, rows)
+
+	rows, err = db.EstimateRows("employees", "no_such_table")
+	assert.NoError(t, err)
+	assert.EqualValues(t, -1, rows)
+}
+
 func TestDataSource_PreviewTable(t *testing.T) {
 	// PreviewTable has no ORDER BY, so row order is not guaranteed —
 	// assert the header and set membership, not positions.
-	preview, err := db.PreviewTable("employees", "departments")
+	preview, err := db.PreviewTable("employees", "departments", internal.Preview{})
 
 	assert.NoError(t, err)
 	assert.Len(t, preview, 10)
@@ -145,6 +171,27 @@ func TestDataSource_ExplainTable(t *testing.T) {
 	assert.EqualValues(t, expectedDescribe, describe)
 }
 
+func TestDataSource_ListColumns(t *testing.T) {
+	columns, err := db.ListColumns("employees", "dept_emp")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []internal.Column{
+		{Name: "emp_no", Type: "int", PrimaryKey: true},
+		{Name: "dept_no", Type: "char(4)", PrimaryKey: true},
+		{Name: "from_date", Type: "date"},
+		{Name: "to_date", Type: "date"},
+	}, columns)
+
+	columns, err = db.ListColumns("employees", "no_such_table")
+	assert.NoError(t, err)
+	assert.Empty(t, columns)
+
+	all, err := db.ListSchemaColumns("employees")
+	assert.NoError(t, err)
+	assert.Len(t, all["dept_emp"], 4)
+	assert.Contains(t, all, "employees")
+}
+
 func TestDataSource_Query(t *testing.T) {
 	expectedResult := [][]*string{
 		{sptr("dept_no")},
@@ -157,3 +204,42 @@ func TestDataSource_Query(t *testing.T) {
 	assert.Len(t, result, 3)
 	assert.EqualValues(t, expectedResult, result)
 }
+
+func TestDataSource_Execute(t *testing.T) {
+	// The second statement fails, so the demo data stays untouched.
+	_, err := db.Execute("employees", []internal.Statement{
+		{SQL: "UPDATE departments SET dept_name = ? WHERE dept_no = ?", Args: []any{"Renamed", "d001"}},
+		{SQL: "UPDATE no_such_table SET x = 1"},
+	})
+	assert.Error(t, err)
+
+	result, err := db.Query("employees", "select dept_name from departments where dept_no = 'd001'")
+	assert.NoError(t, err)
+	assert.Equal(t, "Marketing", *result[1][0])
+
+	affected, err := db.Execute("employees", []internal.Statement{
+		{SQL: "UPDATE departments SET dept_name = ? WHERE dept_no = ?", Args: []any{"Marketing", "d001"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0}, affected, "MySQL counts changed rows only")
+}
+
+func TestDataSource_Transaction(t *testing.T) {
+	name := func() string {
+		result, err := db.Query("employees", "select dept_name from departments where dept_no = 'd001'")
+		assert.NoError(t, err)
+		return *result[1][0]
+	}
+
+	assert.NoError(t, db.Begin())
+	assert.True(t, db.InTransaction())
+	_, err := db.Execute("employees", []internal.Statement{
+		{SQL: "UPDATE departments SET dept_name = ? WHERE dept_no = ?", Args: []any{"Renamed", "d001"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", name(), "queries see the uncommitted changes")
+
+	assert.NoError(t, db.Rollback())
+	assert.False(t, db.InTransaction())
+	assert.Equal(t, "Marketing", name())
+}
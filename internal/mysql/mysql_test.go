@@ -3,6 +3,7 @@ package mysql_test
 import (
 	"testing"
 
+	"github.com/kenanbek/dbui/internal/config"
 	"github.com/kenanbek/dbui/internal/mysql"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,3 +25,11 @@ func TestDataSource_ListTables_Negative(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "connect: connection refused")
 }
+
+func TestNewWithTLS_Negative(t *testing.T) {
+	_, err := mysql.NewWithTLS("root:demo@(localhost:3306)/mysql", &config.TLSConfig{CAFileProp: "testdata/ghost-ca.pem"})
+	assert.Error(t, err)
+
+	_, err = mysql.NewWithTLS("some-random-text", &config.TLSConfig{SkipVerifyProp: true})
+	assert.Error(t, err)
+}
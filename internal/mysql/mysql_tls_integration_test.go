@@ -0,0 +1,102 @@
+//go:build integration
+// +build integration
+
+package mysql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kenanbek/dbui/internal/config"
+	"github.com/kenanbek/dbui/internal/mysql"
+	"github.com/kenanbek/dbui/internal/tlstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDataSource_TLS(t *testing.T) {
+	ctx := context.Background()
+
+	// The server certificate is issued for a name the client never dials,
+	// so verification only passes through the serverName override.
+	certs, err := tlstest.Generate(t.TempDir(), "db.internal")
+	require.NoError(t, err)
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        mysqlImage,
+			Env:          map[string]string{"MYSQL_ROOT_PASSWORD": "demo"},
+			ExposedPorts: []string{"3306/tcp"},
+			Files: []testcontainers.ContainerFile{
+				{HostFilePath: certs.CA, ContainerFilePath: "/certs/ca.pem", FileMode: 0o644},
+				{HostFilePath: certs.ServerCert, ContainerFilePath: "/certs/server-cert.pem", FileMode: 0o644},
+				{HostFilePath: certs.ServerKey, ContainerFilePath: "/certs/server-key.pem", FileMode: 0o644},
+			},
+			Cmd: []string{
+				"mysqld",
+				"--ssl-ca=/certs/ca.pem",
+				"--ssl-cert=/certs/server-cert.pem",
+				"--ssl-key=/certs/server-key.pem",
+				"--require-secure-transport=ON",
+			},
+			// As in TestMain, TCP opens only once initialization is over.
+			WaitingFor: wait.ForListeningPort("3306/tcp").WithStartupTimeout(5 * time.Minute),
+		},
+		Started: true,
+	})
+	testcontainers.CleanupContainer(t, container)
+	require.NoError(t, err)
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "3306/tcp")
+	require.NoError(t, err)
+	dsn := fmt.Sprintf("root:demo@(%s:%s)/mysql", host, port.Port())
+
+	tests := []struct {
+		name    string
+		tls     *config.TLSConfig
+		wantErr bool
+	}{
+		{name: "plain connection is refused", tls: nil, wantErr: true},
+		{name: "host name mismatch", tls: &config.TLSConfig{CAFileProp: certs.CA}, wantErr: true},
+		{name: "unknown authority", tls: &config.TLSConfig{ServerNameProp: "db.internal"}, wantErr: true},
+		{name: "server name override", tls: &config.TLSConfig{CAFileProp: certs.CA, ServerNameProp: "db.internal"}},
+		{
+			name: "client certificate",
+			tls: &config.TLSConfig{
+				CAFileProp:     certs.CA,
+				CertFileProp:   certs.ClientCert,
+				KeyFileProp:    certs.ClientKey,
+				ServerNameProp: "db.internal",
+			},
+		},
+		{name: "skip verify", tls: &config.TLSConfig{SkipVerifyProp: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ds *mysql.DataSource
+			if tt.tls == nil {
+				ds, err = mysql.New(dsn)
+			} else {
+				ds, err = mysql.NewWithTLS(dsn, tt.tls)
+			}
+			require.NoError(t, err)
+
+			if tt.wantErr {
+				assert.Error(t, ds.Ping())
+				return
+			}
+
+			require.NoError(t, ds.Ping())
+			status, err := ds.Query("mysql", "SHOW SESSION STATUS LIKE 'Ssl_cipher'")
+			require.NoError(t, err)
+			require.Len(t, status, 2)
+			assert.NotEmpty(t, *status[1][1])
+		})
+	}
+}
@@ -3,21 +3,33 @@ package postgresql
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/kenanbek/dbui/internal"
-	_ "github.com/lib/pq" // import pq driver for PostgreSQL.
+	"github.com/kenanbek/dbui/internal/sqlgen"
+	"github.com/lib/pq"
 )
 
+var dialect = sqlgen.DialectFor("postgresql")
+
 // DataSource implements internal.DataSource interface for PostgreSQL storage.
 type DataSource struct {
-	db *sql.DB
+	db      *sql.DB
+	session internal.Session
 }
 
+// query runs the query in the session transaction, if one is open.
 func (d *DataSource) query(query string) (data [][]*string, err error) {
-	rows, err := d.db.Query(query)
+	querier := d.session.Querier(d.db)
+	rows, err := querier.Query(query)
 	if err != nil {
+		// PostgreSQL aborts the transaction a statement fails in.
+		if _, ok := querier.(*sql.Tx); ok {
+			err = fmt.Errorf("%w: %w", internal.ErrTransactionAborted, err)
+		}
 		return
 	}
 	defer internal.CloseOrLog(rows)
@@ -53,10 +65,19 @@ func (d *DataSource) query(query string) (data [][]*string, err error) {
 	return
 }
 
+// tlsConfigSeq generates unique keys for the TLS configs registered in the pq driver.
+var tlsConfigSeq atomic.Uint64
+
 // New configures a new connection to the PostgreSQL data source
 // and returns an instance of it which implements internal.DataSource interface.
 func New(dsn string) (*DataSource, error) {
-	db, err := sql.Open("postgres", dsn)
+	return NewWithTLS(dsn, nil)
+}
+
+// NewWithTLS works as New but secures the connection with the provided TLS settings.
+// A nil tlsConfig keeps the sslmode and related parameters defined by the DSN itself.
+func NewWithTLS(dsn string, tlsConfig internal.TLSConfig) (*DataSource, error) {
+	db, err := open(dsn, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -68,11 +89,61 @@ func New(dsn string) (*DataSource, error) {
 	return &DataSource{db: db}, nil
 }
 
+func open(dsn string, tlsConfig internal.TLSConfig) (*sql.DB, error) {
+	if tlsConfig == nil {
+		return sql.Open("postgres", dsn)
+	}
+
+	cfg, err := pq.NewConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := internal.NewTLSConfig(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// pq only accepts a custom tls.Config through its registry, selected by sslmode=pqgo-<key>.
+	key := fmt.Sprintf("dbui%d", tlsConfigSeq.Add(1))
+	if err := pq.RegisterTLSConfig(key, conf); err != nil {
+		return nil, err
+	}
+	cfg.SSLMode = pq.SSLMode("pqgo-" + key)
+	// With SNI enabled pq overwrites the server name with the connection host.
+	if conf.ServerName != "" {
+		cfg.SSLSNI = false
+	}
+
+	connector, err := pq.NewConnectorConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(connector), nil
+}
+
+// ValidateDSN reports whether dsn is a well-formed PostgreSQL connection string or URL.
+func ValidateDSN(dsn string) error {
+	_, err := pq.NewConfig(dsn)
+	return err
+}
+
 // Ping exported.
 func (d *DataSource) Ping() error {
 	return d.db.Ping()
 }
 
+// ServerInfo exported. Standby servers are read-only, as are sessions whose transactions are by default.
+func (d *DataSource) ServerInfo() (info internal.ServerInfo, err error) {
+	info.Engine = "PostgreSQL"
+	err = d.db.QueryRow(`SELECT current_setting('server_version'), current_user,
+		pg_is_in_recovery() OR current_setting('default_transaction_read_only') = 'on'`).
+		Scan(&info.Version, &info.User, &info.ReadOnly)
+
+	return
+}
+
 // ListSchemas exported.
 func (d *DataSource) ListSchemas() (schemas []string, err error) {
 	res, err := d.db.Query("SELECT datname FROM pg_database WHERE datistemplate = false")
@@ -114,11 +185,45 @@ func (d *DataSource) ListTables(schema string) (tables []string, err error) {
 	return
 }
 
+// ListViews exported.
+func (d *DataSource) ListViews(schema string) (views []string, err error) {
+	res, err := d.db.Query("SELECT table_name FROM information_schema.views WHERE table_schema = 'public' AND table_catalog = $1 ORDER BY table_name", schema)
+	if err != nil {
+		return
+	}
+	defer internal.CloseOrLog(res)
+
+	views = []string{}
+	for res.Next() {
+		var view string
+		if err = res.Scan(&view); err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	return views, res.Err()
+}
+
 // PreviewTable exported.
 //
 //nolint:revive // schema is ignored — known wrong PG schema model, fixed in the v1.0 dialect rewrite.
-func (d *DataSource) PreviewTable(schema string, table string) ([][]*string, error) {
-	return d.query(fmt.Sprintf("SELECT * FROM %s LIMIT 50", table))
+func (d *DataSource) PreviewTable(schema string, table string, preview internal.Preview) ([][]*string, error) {
+	return d.query(dialect.Select(dialect.QuoteIdent(table), preview, 50))
+}
+
+// EstimateRows exported. The estimate comes from the statistics gathered by VACUUM and ANALYZE.
+//
+//nolint:revive // schema is ignored — known wrong PG schema model, fixed in the v1.0 dialect rewrite.
+func (d *DataSource) EstimateRows(schema, table string) (int64, error) {
+	var rows int64
+	err := d.db.QueryRow("SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass(quote_ident($1))", table).Scan(&rows)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && rows < 0 {
+		// Tables which were never analyzed have no estimate.
+		return -1, nil
+	}
+
+	return rows, err
 }
 
 // DescribeTable exported.
@@ -129,9 +234,93 @@ func (d *DataSource) DescribeTable(schema string, table string) ([][]*string, er
 	return d.query(query)
 }
 
+// ListColumns exported.
+func (d *DataSource) ListColumns(schema, table string) ([]internal.Column, error) {
+	columns, err := d.listColumns(schema, table)
+	if err != nil {
+		return nil, err
+	}
+	if columns[table] == nil {
+		return []internal.Column{}, nil
+	}
+
+	return columns[table], nil
+}
+
+// ListSchemaColumns exported.
+func (d *DataSource) ListSchemaColumns(schema string) (map[string][]internal.Column, error) {
+	return d.listColumns(schema, "")
+}
+
+// listColumns reads the columns of the table, or of every table and view when table is empty, by table name.
+func (d *DataSource) listColumns(schema, table string) (columns map[string][]internal.Column, err error) {
+	res, err := d.db.Query(`SELECT c.table_name, c.column_name, c.data_type, c.is_nullable = 'YES',
+		EXISTS (SELECT 1 FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage k
+				ON k.constraint_name = tc.constraint_name AND k.table_schema = tc.table_schema AND k.table_name = tc.table_name
+			WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = c.table_schema AND tc.table_name = c.table_name
+				AND k.column_name = c.column_name),
+		c.column_default
+		FROM information_schema.columns c
+		WHERE c.table_catalog = $1 AND c.table_schema = 'public' AND ($2 = '' OR c.table_name = $2)
+		ORDER BY c.table_name, c.ordinal_position`, schema, table)
+	if err != nil {
+		return
+	}
+	defer internal.CloseOrLog(res)
+
+	columns = map[string][]internal.Column{}
+	for res.Next() {
+		var name string
+		var c internal.Column
+		if err = res.Scan(&name, &c.Name, &c.Type, &c.Nullable, &c.PrimaryKey, &c.Default); err != nil {
+			return nil, err
+		}
+		columns[name] = append(columns[name], c)
+	}
+
+	return columns, res.Err()
+}
+
 // Query exported.
 //
 //nolint:revive // schema is ignored — known wrong PG schema model, fixed in the v1.0 dialect rewrite.
 func (d *DataSource) Query(schema, query string) ([][]*string, error) {
 	return d.query(query)
 }
+
+// Execute exported.
+//
+//nolint:revive // schema is ignored — known wrong PG schema model, fixed in the v1.0 dialect rewrite.
+func (d *DataSource) Execute(schema string, statements []internal.Statement) ([]int64, error) {
+	if tx := d.session.Tx(); tx != nil {
+		return internal.ExecuteInSavepoint(tx, statements)
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.ExecuteInTx(tx, statements)
+}
+
+// Begin exported.
+func (d *DataSource) Begin() error {
+	return d.session.Begin(d.db)
+}
+
+// Commit exported.
+func (d *DataSource) Commit() error {
+	return d.session.Commit()
+}
+
+// Rollback exported.
+func (d *DataSource) Rollback() error {
+	return d.session.Rollback()
+}
+
+// InTransaction exported.
+func (d *DataSource) InTransaction() bool {
+	return d.session.Active()
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kenanbek/dbui/internal"
 	"github.com/kenanbek/dbui/internal/postgresql"
 	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
@@ -106,10 +107,35 @@ func TestDataSource_ListTables(t *testing.T) {
 	assert.Empty(t, tables)
 }
 
+func TestDataSource_ListViews(t *testing.T) {
+	views, err := db.ListViews("world-db")
+	assert.NoError(t, err)
+	assert.Empty(t, views)
+}
+
+func TestDataSource_ServerInfo(t *testing.T) {
+	info, err := db.ServerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "PostgreSQL", info.Engine)
+	assert.NotEmpty(t, info.Version)
+	assert.NotEmpty(t, info.User)
+	assert.False(t, info.ReadOnly)
+}
+
+func TestDataSource_EstimateRows(t *testing.T) {
+	rows, err := db.EstimateRows("world-db", "country_language")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, rows, int64(-1))
+
+	rows, err = db.EstimateRows("world-db", "no_such_table")
+	assert.NoError(t, err)
+	assert.EqualValues(t, -1, rows)
+}
+
 func TestDataSource_PreviewTable(t *testing.T) {
 	// PreviewTable has no ORDER BY, so row order is not guaranteed —
 	// assert the header and set membership, not positions.
-	preview, err := db.PreviewTable("world-db", "country_language")
+	preview, err := db.PreviewTable("world-db", "country_language", internal.Preview{})
 
 	assert.NoError(t, err)
 	assert.Len(t, preview, 51)
@@ -134,6 +160,27 @@ func TestDataSource_ExplainTable(t *testing.T) {
 	assert.EqualValues(t, expectedDescribe, describe)
 }
 
+func TestDataSource_ListColumns(t *testing.T) {
+	columns, err := db.ListColumns("world-db", "country_language")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []internal.Column{
+		{Name: "country_code", Type: "character", PrimaryKey: true},
+		{Name: "language", Type: "text", PrimaryKey: true},
+		{Name: "is_official", Type: "boolean"},
+		{Name: "percentage", Type: "real"},
+	}, columns)
+
+	columns, err = db.ListColumns("world-db", "no_such_table")
+	assert.NoError(t, err)
+	assert.Empty(t, columns)
+
+	all, err := db.ListSchemaColumns("world-db")
+	assert.NoError(t, err)
+	assert.Len(t, all["country_language"], 4)
+	assert.Contains(t, all, "country")
+}
+
 func TestDataSource_Query(t *testing.T) {
 	expectedResult := [][]*string{
 		{sptr("country_code")},
@@ -145,3 +192,39 @@ func TestDataSource_Query(t *testing.T) {
 	assert.Len(t, result, 2)
 	assert.EqualValues(t, expectedResult, result)
 }
+
+func TestDataSource_Execute(t *testing.T) {
+	// The second statement fails, so the demo data stays untouched.
+	_, err := db.Execute("world-db", []internal.Statement{
+		{SQL: "UPDATE country_language SET percentage = $1 WHERE country_code = $2 AND language = $3", Args: []any{"0", "ABW", "Dutch"}},
+		{SQL: "UPDATE no_such_table SET x = 1"},
+	})
+	assert.Error(t, err)
+
+	result, err := db.Query("world-db", "select percentage from country_language where country_code = 'ABW' and language = 'Dutch'")
+	assert.NoError(t, err)
+	assert.Equal(t, "5.3", *result[1][0])
+}
+
+func TestDataSource_Transaction(t *testing.T) {
+	percentage := func() string {
+		result, err := db.Query("world-db", "select percentage from country_language where country_code = 'ABW' and language = 'Dutch'")
+		assert.NoError(t, err)
+		return *result[1][0]
+	}
+
+	assert.NoError(t, db.Begin())
+	assert.True(t, db.InTransaction())
+	_, err := db.Execute("world-db", []internal.Statement{
+		{SQL: "UPDATE country_language SET percentage = $1 WHERE country_code = $2 AND language = $3", Args: []any{"0", "ABW", "Dutch"}},
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, "5.3", percentage(), "queries see the uncommitted changes")
+
+	_, err = db.Query("world-db", "SELECT 1 / 0")
+	assert.ErrorIs(t, err, internal.ErrTransactionAborted, "a failed query aborts the transaction")
+
+	assert.NoError(t, db.Rollback())
+	assert.False(t, db.InTransaction())
+	assert.Equal(t, "5.3", percentage())
+}
@@ -3,6 +3,7 @@ package postgresql_test
 import (
 	"testing"
 
+	"github.com/kenanbek/dbui/internal/config"
 	"github.com/kenanbek/dbui/internal/postgresql"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,3 +23,11 @@ func TestDataSource_ListTables_Negative(t *testing.T) {
 	_, err = db2.ListTables("world-db")
 	assert.Error(t, err)
 }
+
+func TestNewWithTLS_Negative(t *testing.T) {
+	_, err := postgresql.NewWithTLS("user=wrong password=wrong host=localhost", &config.TLSConfig{CAFileProp: "testdata/ghost-ca.pem"})
+	assert.Error(t, err)
+
+	_, err = postgresql.NewWithTLS("port=not-a-port", &config.TLSConfig{SkipVerifyProp: true})
+	assert.Error(t, err)
+}
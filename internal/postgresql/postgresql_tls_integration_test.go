@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+package postgresql_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kenanbek/dbui/internal/config"
+	"github.com/kenanbek/dbui/internal/postgresql"
+	"github.com/kenanbek/dbui/internal/tlstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestDataSource_TLS(t *testing.T) {
+	ctx := context.Background()
+
+	// The server certificate is issued for a name the client never dials,
+	// so verification only passes through the serverName override.
+	certs, err := tlstest.Generate(t.TempDir(), "db.internal")
+	require.NoError(t, err)
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        pgImage,
+			ExposedPorts: []string{"5432/tcp"},
+			Files: []testcontainers.ContainerFile{
+				{HostFilePath: certs.ServerCert, ContainerFilePath: "/certs/server-cert.pem", FileMode: 0o644},
+				{HostFilePath: certs.ServerKey, ContainerFilePath: "/certs/server-key.pem", FileMode: 0o644},
+			},
+			// PostgreSQL refuses key files readable by others or owned by another user,
+			// so the key is copied with the right owner before the regular entrypoint runs.
+			Entrypoint: []string{"sh", "-c", "install -o postgres -m 600 /certs/server-key.pem /tmp/server-key.pem && " +
+				"exec docker-entrypoint.sh postgres -c ssl=on -c ssl_cert_file=/certs/server-cert.pem -c ssl_key_file=/tmp/server-key.pem"},
+			WaitingFor: wait.ForListeningPort("5432/tcp").WithStartupTimeout(5 * time.Minute),
+		},
+		Started: true,
+	})
+	testcontainers.CleanupContainer(t, container)
+	require.NoError(t, err)
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "5432/tcp")
+	require.NoError(t, err)
+	dsn := fmt.Sprintf("user=world password=world123 host=%s port=%s dbname=world-db sslmode=disable", host, port.Port())
+
+	tests := []struct {
+		name    string
+		tls     *config.TLSConfig
+		wantErr bool
+	}{
+		{name: "host name mismatch", tls: &config.TLSConfig{CAFileProp: certs.CA}, wantErr: true},
+		{name: "unknown authority", tls: &config.TLSConfig{ServerNameProp: "db.internal"}, wantErr: true},
+		{name: "server name override", tls: &config.TLSConfig{CAFileProp: certs.CA, ServerNameProp: "db.internal"}},
+		{
+			name: "client certificate",
+			tls: &config.TLSConfig{
+				CAFileProp:     certs.CA,
+				CertFileProp:   certs.ClientCert,
+				KeyFileProp:    certs.ClientKey,
+				ServerNameProp: "db.internal",
+			},
+		},
+		{name: "skip verify", tls: &config.TLSConfig{SkipVerifyProp: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds, err := postgresql.NewWithTLS(dsn, tt.tls)
+			require.NoError(t, err)
+
+			if tt.wantErr {
+				assert.Error(t, ds.Ping())
+				return
+			}
+
+			require.NoError(t, ds.Ping())
+			// The TLS section takes precedence over sslmode=disable in the DSN.
+			ssl, err := ds.Query("world-db", "SELECT ssl FROM pg_stat_ssl WHERE pid = pg_backend_pid()")
+			require.NoError(t, err)
+			assert.EqualValues(t, [][]*string{{sptr("ssl")}, {sptr("true")}}, ssl)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package pretty
+
+import (
+	"strings"
+)
+
+// Class tells how a span of a document is highlighted.
+type Class int
+
+const (
+	// Plain text, punctuation and whitespace.
+	Plain Class = iota
+	// Key is the name of a JSON member or an XML attribute.
+	Key
+	// String is a JSON string or an XML attribute value.
+	String
+	// Number is a JSON number.
+	Number
+	// Literal is true, false or null in JSON.
+	Literal
+	// Tag is the name of an XML element, with its angle brackets.
+	Tag
+	// Comment is an XML comment.
+	Comment
+)
+
+// Span is a highlighted part of a document, from the byte offset Start up to End.
+type Span struct {
+	Start, End int
+	Class      Class
+}
+
+// Highlight splits a document of the kind into spans. Text is highlighted as a single plain span,
+// and so are the parts of a document which cannot be parsed.
+func Highlight(text string, kind Kind) []Span {
+	switch kind {
+	case JSON:
+		return highlightJSON(text)
+	case XML:
+		return highlightXML(text)
+	default:
+		return []Span{{0, len(text), Plain}}
+	}
+}
+
+func highlightJSON(text string) []Span {
+	var spans []Span
+	add := func(start, end int, class Class) {
+		spans = append(spans, Span{start, end, class})
+	}
+
+	for i := 0; i < len(text); {
+		start := i
+		switch c := text[i]; {
+		case c == '"':
+			i = stringEnd(text, i)
+			class := String
+			if rest := strings.TrimLeft(text[i:], " \t\r\n"); strings.HasPrefix(rest, ":") {
+				class = Key
+			}
+			add(start, i, class)
+		case c == '-' || c >= '0' && c <= '9':
+			for i < len(text) && strings.IndexByte("+-.0123456789eE", text[i]) >= 0 {
+				i++
+			}
+			add(start, i, Number)
+		case c >= 'a' && c <= 'z':
+			for i < len(text) && text[i] >= 'a' && text[i] <= 'z' {
+				i++
+			}
+			add(start, i, Literal)
+		default:
+			for i < len(text) && strings.IndexByte("\"-0123456789abcdefghijklmnopqrstuvwxyz", text[i]) < 0 {
+				i++
+			}
+			add(start, i, Plain)
+		}
+	}
+
+	return spans
+}
+
+// stringEnd returns the offset after the JSON string starting at i.
+func stringEnd(text string, i int) int {
+	for i++; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(text)
+}
+
+func highlightXML(text string) []Span {
+	var spans []Span
+	add := func(start, end int, class Class) {
+		if end > start {
+			spans = append(spans, Span{start, end, class})
+		}
+	}
+
+	for i := 0; i < len(text); {
+		start := i
+		switch {
+		case strings.HasPrefix(text[i:], "<!--"):
+			end := strings.Index(text[i:], "-->")
+			if end < 0 {
+				i = len(text)
+			} else {
+				i += end + len("-->")
+			}
+			add(start, i, Comment)
+		case text[i] == '<':
+			i = highlightXMLTag(text, i, add)
+		default:
+			if end := strings.IndexByte(text[i:], '<'); end < 0 {
+				i = len(text)
+			} else {
+				i += end
+			}
+			add(start, i, Plain)
+		}
+	}
+
+	return spans
+}
+
+// highlightXMLTag highlights the tag starting at i, e.g. `<item id="1"/>`, and returns the offset after it.
+func highlightXMLTag(text string, i int, add func(start, end int, class Class)) int {
+	start := i
+	i++
+	// Closing tags, processing instructions and directives.
+	if i < len(text) && strings.IndexByte("/?!", text[i]) >= 0 {
+		i++
+	}
+	for i < len(text) && !isXMLSpace(text[i]) && strings.IndexByte(">/?", text[i]) < 0 {
+		i++
+	}
+	add(start, i, Tag)
+
+	for i < len(text) {
+		start = i
+		switch c := text[i]; {
+		case c == '>' || (c == '/' || c == '?') && strings.HasPrefix(text[i+1:], ">"):
+			end := i + 1
+			if c != '>' {
+				end++
+			}
+			add(i, end, Tag)
+			return end
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(text[i+1:], c)
+			if end < 0 {
+				add(i, len(text), String)
+				return len(text)
+			}
+			i += end + 2
+			add(start, i, String)
+		case isXMLSpace(c) || c == '=':
+			for i < len(text) && (isXMLSpace(text[i]) || text[i] == '=') {
+				i++
+			}
+			add(start, i, Plain)
+		default:
+			for i < len(text) && !isXMLSpace(text[i]) && strings.IndexByte("=>/\"'", text[i]) < 0 {
+				i++
+			}
+			if i == start {
+				i++
+			}
+			add(start, i, Key)
+		}
+	}
+
+	return i
+}
+
+func isXMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
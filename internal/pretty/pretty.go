@@ -0,0 +1,95 @@
+// Package pretty recognizes JSON and XML documents stored in text values,
+// indents them for reading and splits them into spans for highlighting.
+package pretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Kind is the format of a value.
+type Kind int
+
+const (
+	// Text is any value which is neither JSON nor XML.
+	Text Kind = iota
+	// JSON is a JSON object or array.
+	JSON
+	// XML is an XML document or fragment.
+	XML
+)
+
+// String returns the lower case name of the kind.
+func (k Kind) String() string {
+	return [...]string{"text", "json", "xml"}[k]
+}
+
+// indent is the indentation of one nesting level.
+const indent = "  "
+
+// Format indents a JSON or XML value and returns it with its kind. Other values are returned unchanged as Text.
+func Format(value string) (string, Kind) {
+	trimmed := strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		var b bytes.Buffer
+		if err := json.Indent(&b, []byte(trimmed), "", indent); err == nil {
+			return b.String(), JSON
+		}
+	case strings.HasPrefix(trimmed, "<"):
+		if formatted, err := formatXML(trimmed); err == nil {
+			return formatted, XML
+		}
+	}
+
+	return value, Text
+}
+
+// formatXML re-encodes the XML with indentation, dropping the whitespace between elements.
+func formatXML(value string) (string, error) {
+	var b bytes.Buffer
+	decoder := xml.NewDecoder(strings.NewReader(value))
+	encoder := xml.NewEncoder(&b)
+	encoder.Indent("", indent)
+
+	elements := 0
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) == 0 {
+				continue
+			}
+		case xml.StartElement:
+			elements++
+		}
+		if err := encoder.EncodeToken(token); err != nil {
+			return "", err
+		}
+		// The encoder indents elements only, so the declaration gets a line of its own here.
+		if _, ok := token.(xml.ProcInst); ok && elements == 0 {
+			if err := encoder.Flush(); err != nil {
+				return "", err
+			}
+			b.WriteByte('\n')
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		return "", err
+	}
+	if elements == 0 {
+		return "", errors.New("no elements")
+	}
+
+	return b.String(), nil
+}
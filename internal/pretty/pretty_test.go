@@ -0,0 +1,69 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		kind  Kind
+	}{
+		{"object", `{"id":1,"tags":["a","b"]}`, "{\n  \"id\": 1,\n  \"tags\": [\n    \"a\",\n    \"b\"\n  ]\n}", JSON},
+		{"array with spaces", ` [1, 2] `, "[\n  1,\n  2\n]", JSON},
+		{"broken JSON", `{"id":`, `{"id":`, Text},
+		{"JSON scalar", `42`, `42`, Text},
+		{"XML", "<a x=\"1\">\n <b>text</b><c/></a>", "<a x=\"1\">\n  <b>text</b>\n  <c></c>\n</a>", XML},
+		{"XML declaration", `<?xml version="1.0"?><a/>`, "<?xml version=\"1.0\"?>\n<a></a>", XML},
+		{"broken XML", `<a><b></a>`, `<a><b></a>`, Text},
+		{"plain text", "less < more", "less < more", Text},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, kind := Format(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.kind, kind)
+		})
+	}
+}
+
+// classes returns the text of every span which is not plain, with its class.
+func classes(text string, spans []Span) [][2]any {
+	var result [][2]any
+	end := 0
+	for _, s := range spans {
+		if s.Start != end {
+			panic("spans are not contiguous")
+		}
+		end = s.End
+		if s.Class != Plain {
+			result = append(result, [2]any{text[s.Start:s.End], s.Class})
+		}
+	}
+	if end != len(text) {
+		panic("spans do not cover the text")
+	}
+	return result
+}
+
+func TestHighlight(t *testing.T) {
+	text := `{"id": -1.5e3, "name": "a \"b\"", "ok": true, "tags": [null]}`
+	assert.Equal(t, [][2]any{
+		{`"id"`, Key}, {`-1.5e3`, Number}, {`"name"`, Key}, {`"a \"b\""`, String},
+		{`"ok"`, Key}, {`true`, Literal}, {`"tags"`, Key}, {`null`, Literal},
+	}, classes(text, Highlight(text, JSON)))
+
+	text = `<?xml version="1.0"?><a id='1'><!-- note --><b/>x</a>`
+	assert.Equal(t, [][2]any{
+		{`<?xml`, Tag}, {`version`, Key}, {`"1.0"`, String}, {`?>`, Tag},
+		{`<a`, Tag}, {`id`, Key}, {`'1'`, String}, {`>`, Tag}, {`<!-- note -->`, Comment},
+		{`<b`, Tag}, {`/>`, Tag}, {`</a`, Tag}, {`>`, Tag},
+	}, classes(text, Highlight(text, XML)))
+
+	assert.Equal(t, []Span{{0, 5, Plain}}, Highlight("hello", Text))
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrTransactionOpen is returned when beginning a transaction while one is open.
+	ErrTransactionOpen = errors.New("a transaction is already open")
+	// ErrNoTransaction is returned when committing or rolling back without an open transaction.
+	ErrNoTransaction = errors.New("no transaction is open")
+	// ErrTransactionAborted wraps the error of a statement which aborted the open transaction: the data source refuses
+	// every further statement in it until it is rolled back.
+	ErrTransactionAborted = errors.New("the transaction is aborted")
+)
+
+// Querier runs statements, either on a connection pool or in a transaction.
+type Querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// Session holds the transaction the user opened on a data source. The transaction pins a connection of the pool, so
+// the statements run in it see the uncommitted changes of the earlier ones. The zero value has no transaction open.
+// It is safe for concurrent use, the transaction it hands out is not.
+type Session struct {
+	mu sync.Mutex
+	tx *sql.Tx
+}
+
+// Begin opens a transaction on a connection of the pool.
+func (s *Session) Begin(db *sql.DB) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tx != nil {
+		return ErrTransactionOpen
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	s.tx = tx
+
+	return nil
+}
+
+// Commit commits the transaction. The transaction is over even when committing fails.
+func (s *Session) Commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tx == nil {
+		return ErrNoTransaction
+	}
+
+	tx := s.tx
+	s.tx = nil
+	return tx.Commit()
+}
+
+// Rollback rolls the transaction back.
+func (s *Session) Rollback() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tx == nil {
+		return ErrNoTransaction
+	}
+
+	tx := s.tx
+	s.tx = nil
+	return tx.Rollback()
+}
+
+// Tx returns the open transaction, or nil.
+func (s *Session) Tx() *sql.Tx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tx
+}
+
+// Active reports whether a transaction is open.
+func (s *Session) Active() bool {
+	return s.Tx() != nil
+}
+
+// Querier returns the open transaction, or else the pool.
+func (s *Session) Querier(db *sql.DB) Querier {
+	if tx := s.Tx(); tx != nil {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,201 @@
+// Package snippets loads the saved queries library: a directory of .sql files,
+// each holding one named query with front-matter describing it.
+//
+// The front-matter is a YAML block at the top of the file, either between two
+// `---` lines, or between two `-- ---` lines with every line commented out,
+// which keeps the file valid SQL:
+//
+//	-- ---
+//	-- name: Slow queries
+//	-- description: Statements running longer than a minute
+//	-- types: [postgresql]
+//	-- ---
+//	SELECT pid, query FROM pg_stat_activity WHERE now() - query_start > :threshold::interval;
+package snippets
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kenanbek/dbui/internal/config"
+	"github.com/kenanbek/dbui/internal/sqlgen"
+	"github.com/kenanbek/dbui/internal/sqlparse"
+	"gopkg.in/yaml.v3"
+)
+
+// Extension is the file extension of saved queries.
+const Extension = ".sql"
+
+// Snippet is a saved query.
+type Snippet struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Types limits the snippet to data sources of these types, e.g. postgresql. Empty means any type.
+	Types []string `yaml:"types"`
+	// Aliases limits the snippet to the data sources with these aliases. Empty means any data source.
+	Aliases []string `yaml:"aliases"`
+
+	SQL  string `yaml:"-"`
+	File string `yaml:"-"`
+}
+
+// DefaultDir returns the directory of saved queries used when the configuration sets none,
+// $XDG_CONFIG_HOME/dbui/snippets next to the global configuration file.
+func DefaultDir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "snippets"), nil
+}
+
+// Load reads every .sql file of the directory and its subdirectories, sorted by snippet name.
+// A missing directory is an empty library. Files which cannot be parsed are reported together
+// in the error, while the other snippets are still returned.
+func Load(dir string) ([]Snippet, error) {
+	var (
+		snippets []Snippet
+		errs     []error
+	)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && path == dir:
+			return fs.SkipAll
+		case err != nil:
+			return err
+		case d.IsDir() || !strings.EqualFold(filepath.Ext(path), Extension):
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err == nil {
+			var s Snippet
+			if s, err = Parse(path, content); err == nil {
+				snippets = append(snippets, s)
+				return nil
+			}
+		}
+		errs = append(errs, err)
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	sort.SliceStable(snippets, func(i, j int) bool {
+		return strings.ToLower(snippets[i].Name) < strings.ToLower(snippets[j].Name)
+	})
+
+	return snippets, errors.Join(errs...)
+}
+
+// Parse reads a snippet from the content of the file. Without front-matter, or a name in it,
+// the snippet is named after the file.
+func Parse(file string, content []byte) (Snippet, error) {
+	s := Snippet{File: file}
+
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	header, body, err := frontMatter(text)
+	if err != nil {
+		return s, fmt.Errorf("%s: %w", file, err)
+	}
+	if err := yaml.Unmarshal([]byte(header), &s); err != nil {
+		return s, fmt.Errorf("%s: front-matter: %w", file, err)
+	}
+
+	s.SQL = strings.TrimSpace(body)
+	if s.Name == "" {
+		s.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	return s, nil
+}
+
+// frontMatter splits the text into its front-matter, with comment markers removed, and the rest.
+func frontMatter(text string) (header, body string, err error) {
+	first, rest, _ := strings.Cut(text, "\n")
+	delimiter := strings.TrimSpace(first)
+	if delimiter != "---" && delimiter != "-- ---" {
+		return "", text, nil
+	}
+	commented := delimiter == "-- ---"
+
+	var lines []string
+	for rest != "" {
+		var line string
+		line, rest, _ = strings.Cut(rest, "\n")
+		if strings.TrimSpace(line) == delimiter {
+			return strings.Join(lines, "\n"), rest, nil
+		}
+		if commented {
+			if !strings.HasPrefix(line, "--") {
+				return "", "", fmt.Errorf("front-matter line %q is not commented out", line)
+			}
+			line = strings.TrimPrefix(strings.TrimPrefix(line, "--"), " ")
+		}
+		lines = append(lines, line)
+	}
+
+	return "", "", errors.New("front-matter is not terminated")
+}
+
+// Applies reports whether the snippet is meant for the data source with the alias and type.
+func (s Snippet) Applies(alias, typ string) bool {
+	return (len(s.Aliases) == 0 || contains(s.Aliases, alias)) && (len(s.Types) == 0 || contains(s.Types, typ))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Params returns the names of the `:name` placeholders of the query, each once, in order of appearance.
+// Placeholders inside strings and comments, and type casts like `::int`, are not parameters.
+func (s Snippet) Params() []string {
+	var params []string
+	seen := map[string]bool{}
+	for _, name := range placeholders(s.SQL) {
+		if !seen[name] {
+			seen[name] = true
+			params = append(params, name)
+		}
+	}
+
+	return params
+}
+
+func placeholders(sql string) []string {
+	var names []string
+	for _, t := range sqlparse.Tokenize(sql) {
+		if t.Kind == sqlparse.Parameter && strings.HasPrefix(t.Text, ":") {
+			names = append(names, t.Text[1:])
+		}
+	}
+	return names
+}
+
+// Bind returns the query with its placeholders replaced by the values as SQL literals of the data source type:
+// numbers are kept as they are and everything else becomes a quoted string.
+func (s Snippet) Bind(values map[string]string, typ string) string {
+	dialect := sqlgen.DialectFor(typ)
+	var b strings.Builder
+	for _, t := range sqlparse.Tokenize(s.SQL) {
+		if value, ok := values[strings.TrimPrefix(t.Text, ":")]; ok && t.Kind == sqlparse.Parameter && strings.HasPrefix(t.Text, ":") {
+			b.WriteString(dialect.Literal(value))
+			continue
+		}
+		b.WriteString(t.Text)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package snippets
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Snippet
+		wantErr string
+	}{
+		{
+			name:    "commented front-matter",
+			content: "-- ---\n-- name: Active\n-- description: Running sessions\n-- types: [postgresql, mysql]\n-- ---\nSELECT 1;\n",
+			want:    Snippet{Name: "Active", Description: "Running sessions", Types: []string{"postgresql", "mysql"}, SQL: "SELECT 1;"},
+		},
+		{
+			name:    "plain front-matter",
+			content: "---\r\nname: Active\r\naliases: [prod]\r\n---\r\nSELECT 1;\r\n",
+			want:    Snippet{Name: "Active", Aliases: []string{"prod"}, SQL: "SELECT 1;"},
+		},
+		{
+			name:    "no front-matter",
+			content: "-- just a comment\nSELECT 1;",
+			want:    Snippet{Name: "active", SQL: "-- just a comment\nSELECT 1;"},
+		},
+		{
+			name:    "front-matter without name",
+			content: "---\ndescription: Running sessions\n---\nSELECT 1;",
+			want:    Snippet{Name: "active", Description: "Running sessions", SQL: "SELECT 1;"},
+		},
+		{name: "unterminated", content: "---\nname: Active\nSELECT 1;", wantErr: "front-matter is not terminated"},
+		{name: "uncommented line", content: "-- ---\nname: Active\n-- ---\nSELECT 1;", wantErr: "is not commented out"},
+		{name: "invalid yaml", content: "---\nname: [Active\n---\nSELECT 1;", wantErr: "active.sql: front-matter: yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse("queries/active.sql", []byte(tt.content))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.want.File = "queries/active.sql"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	snippets, err := Load("testdata/library")
+	assert.ErrorContains(t, err, filepath.Join("testdata", "library", "broken.sql"))
+
+	var names []string
+	for _, s := range snippets {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"count", "Locks", "Slow queries"}, names, "snippets of subdirectories are included, sorted by name")
+
+	snippets, err = Load("testdata/missing")
+	assert.NoError(t, err, "a missing directory is an empty library")
+	assert.Empty(t, snippets)
+}
+
+func TestSnippet_Applies(t *testing.T) {
+	assert.True(t, Snippet{}.Applies("local", "sqlite"))
+
+	pg := Snippet{Types: []string{"postgresql"}}
+	assert.True(t, pg.Applies("prod", "postgresql"))
+	assert.False(t, pg.Applies("prod", "mysql"))
+
+	prod := Snippet{Types: []string{"postgresql"}, Aliases: []string{"Prod"}}
+	assert.True(t, prod.Applies("prod", "postgresql"))
+	assert.False(t, prod.Applies("staging", "postgresql"))
+}
+
+func TestSnippet_Params(t *testing.T) {
+	s := Snippet{SQL: "SELECT ':skipped', a::int -- :comment\nFROM t WHERE a = :a AND b BETWEEN :low AND :high OR c = :a"}
+	assert.Equal(t, []string{"a", "low", "high"}, s.Params())
+	assert.Empty(t, Snippet{SQL: "SELECT 1"}.Params())
+}
+
+func TestSnippet_Bind(t *testing.T) {
+	s := Snippet{SQL: "SELECT * FROM t WHERE id = :id AND name = :name AND note = :note::text -- :id"}
+	values := map[string]string{"id": "-1.5e3", "name": "O'Brien", "note": `C:\temp`}
+
+	assert.Equal(t, `SELECT * FROM t WHERE id = -1.5e3 AND name = 'O''Brien' AND note = 'C:\temp'::text -- :id`, s.Bind(values, "postgresql"))
+	assert.Equal(t, `SELECT * FROM t WHERE id = -1.5e3 AND name = 'O''Brien' AND note = 'C:\\temp'::text -- :id`, s.Bind(values, "mysql"))
+	assert.Equal(t, "SELECT '0x10', '12 '", Snippet{SQL: "SELECT :a, :b"}.Bind(map[string]string{"a": "0x10", "b": "12 "}, "sqlite"))
+}
+
+func TestDefaultDir(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+
+	dir, err := DefaultDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/tmp/xdg", "dbui", "snippets"), dir)
+}
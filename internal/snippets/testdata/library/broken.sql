@@ -0,0 +1,4 @@
+-- ---
+-- name: [unterminated
+-- ---
+SELECT 1;
@@ -0,0 +1 @@
+SELECT COUNT(*) FROM :table_name;
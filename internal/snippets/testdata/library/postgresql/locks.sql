@@ -0,0 +1,5 @@
+---
+name: Locks
+aliases: [prod, staging]
+---
+SELECT * FROM pg_locks WHERE pid = :pid;
@@ -0,0 +1,8 @@
+-- ---
+-- name: Slow queries
+-- description: Statements running longer than a threshold
+-- types: [postgresql]
+-- ---
+SELECT pid, query
+FROM pg_stat_activity
+WHERE now() - query_start > :threshold::interval;
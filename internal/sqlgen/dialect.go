@@ -0,0 +1,297 @@
+// Package sqlgen builds SQL text for the supported database types:
+// quoted identifiers, literals and simple statements.
+package sqlgen
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kenanbek/dbui/internal"
+)
+
+// Dialect describes how a database type quotes identifiers and strings.
+type Dialect struct {
+	// Type is the data source type, e.g. postgresql.
+	Type string
+	// identQuote quotes identifiers.
+	identQuote string
+	// backslashEscapes is set when backslashes in strings start escape sequences, as they do in MySQL.
+	backslashEscapes bool
+}
+
+// DialectFor returns the dialect of the data source type. Unknown types get standard SQL quoting.
+func DialectFor(typ string) Dialect {
+	if typ == "mysql" {
+		return Dialect{Type: typ, identQuote: "`", backslashEscapes: true}
+	}
+
+	return Dialect{Type: typ, identQuote: `"`}
+}
+
+// QuoteIdent quotes a table or column name.
+func (d Dialect) QuoteIdent(name string) string {
+	return d.identQuote + strings.ReplaceAll(name, d.identQuote, d.identQuote+d.identQuote) + d.identQuote
+}
+
+// QuoteTable quotes a table name, qualified by the schema if given.
+func (d Dialect) QuoteTable(schema, table string) string {
+	if schema == "" {
+		return d.QuoteIdent(table)
+	}
+	return d.QuoteIdent(schema) + "." + d.QuoteIdent(table)
+}
+
+// Quote returns the value as a string literal.
+func (d Dialect) Quote(value string) string {
+	if d.backslashEscapes {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+var number = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// Literal returns the value as a numeric literal if it is a number, and as a string literal otherwise.
+func (d Dialect) Literal(value string) string {
+	if number.MatchString(value) {
+		return value
+	}
+
+	return d.Quote(value)
+}
+
+// Value returns a value read from the database as a literal: NULL for nil, a string literal otherwise.
+// Numbers are quoted as well, so that text like zip codes keeps its leading zeros; the databases convert
+// quoted numbers for numeric columns.
+func (d Dialect) Value(value *string) string {
+	if value == nil {
+		return "NULL"
+	}
+
+	return d.Quote(*value)
+}
+
+// Insert returns an INSERT statement adding the values to the columns of the table.
+func (d Dialect) Insert(table string, columns []string, values []*string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = d.QuoteIdent(c)
+	}
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = d.Value(v)
+	}
+
+	return "INSERT INTO " + table + " (" + strings.Join(quoted, ", ") + ") VALUES (" + strings.Join(literals, ", ") + ");"
+}
+
+// Placeholder returns the placeholder of the n-th argument of a statement, counting from 1.
+func (d Dialect) Placeholder(n int) string {
+	if d.Type == "postgresql" {
+		return "$" + strconv.Itoa(n)
+	}
+
+	return "?"
+}
+
+// Arg returns a value read from the database as a statement argument: nil for NULL, a string otherwise.
+func Arg(value *string) any {
+	if value == nil {
+		return nil
+	}
+
+	return *value
+}
+
+// Update returns a statement setting the columns to the values in the row of the table whose key columns
+// have the key values, e.g. UPDATE "t" SET "name" = $1 WHERE "id" = $2.
+func (d Dialect) Update(table string, columns []string, values []*string, keys []string, keyValues []*string) internal.Statement {
+	var args []any
+	placeholder := func(v *string) string {
+		args = append(args, Arg(v))
+		return d.Placeholder(len(args))
+	}
+
+	set := make([]string, len(columns))
+	for i, c := range columns {
+		set[i] = d.QuoteIdent(c) + " = " + placeholder(values[i])
+	}
+
+	return internal.Statement{
+		SQL:  "UPDATE " + table + " SET " + strings.Join(set, ", ") + " WHERE " + d.where(keys, keyValues, placeholder),
+		Args: args,
+	}
+}
+
+// where returns the condition matching the key columns to the values, adding each value as an argument.
+func (d Dialect) where(keys []string, values []*string, placeholder func(*string) string) string {
+	conditions := make([]string, len(keys))
+	for i, k := range keys {
+		conditions[i] = d.QuoteIdent(k) + " = " + placeholder(values[i])
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// Describe returns the statement as text for reviewing it: the SQL followed by a comment listing its arguments.
+func (d Dialect) Describe(s internal.Statement) string {
+	var b strings.Builder
+	b.WriteString(s.SQL)
+	b.WriteString(";")
+	for i, arg := range s.Args {
+		value := "NULL"
+		if v, ok := arg.(string); ok {
+			value = d.Quote(v)
+		}
+		b.WriteString("\n-- " + strconv.Itoa(i+1) + ": " + value)
+	}
+
+	return b.String()
+}
+
+// InsertRow returns a statement adding a row with the values to the columns of the table. Columns left out get
+// their default values.
+func (d Dialect) InsertRow(table string, columns []string, values []*string) internal.Statement {
+	if len(columns) == 0 {
+		if d.Type == "mysql" {
+			return internal.Statement{SQL: "INSERT INTO " + table + " () VALUES ()"}
+		}
+		return internal.Statement{SQL: "INSERT INTO " + table + " DEFAULT VALUES"}
+	}
+
+	quoted := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	args := make([]any, len(columns))
+	for i, c := range columns {
+		quoted[i] = d.QuoteIdent(c)
+		placeholders[i] = d.Placeholder(i + 1)
+		args[i] = Arg(values[i])
+	}
+
+	return internal.Statement{
+		SQL:  "INSERT INTO " + table + " (" + strings.Join(quoted, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")",
+		Args: args,
+	}
+}
+
+// Delete returns a statement removing the row of the table whose key columns have the key values.
+func (d Dialect) Delete(table string, keys []string, keyValues []*string) internal.Statement {
+	var args []any
+	where := d.where(keys, keyValues, func(v *string) string {
+		args = append(args, Arg(v))
+		return d.Placeholder(len(args))
+	})
+
+	return internal.Statement{SQL: "DELETE FROM " + table + " WHERE " + where, Args: args}
+}
+
+// Select returns the query previewing a page of the table, narrowed by the condition of the preview and sorted by its
+// columns, or else by its key. Pages of rows sorted by the key start after, or end before, the given key values.
+// limit applies when the preview does not set one. The condition is parenthesized, so that a trailing comment
+// in it breaks the query instead of dropping the limit.
+func (d Dialect) Select(table string, preview internal.Preview, limit int) string {
+	if preview.Limit > 0 {
+		limit = preview.Limit
+	}
+
+	var conditions []string
+	if preview.Where != "" {
+		conditions = append(conditions, "("+preview.Where+")")
+	}
+
+	order := preview.OrderBy
+	backward := false
+	if len(order) == 0 && len(preview.Key) > 0 {
+		backward = preview.Before != nil && preview.After == nil
+		for _, k := range preview.Key {
+			order = append(order, internal.Order{Column: k, Descending: backward})
+		}
+		switch {
+		case backward:
+			conditions = append(conditions, d.rowValue(preview.Key, nil)+" < "+d.rowValue(nil, preview.Before))
+		case preview.After != nil:
+			conditions = append(conditions, d.rowValue(preview.Key, nil)+" > "+d.rowValue(nil, preview.After))
+		}
+	}
+
+	query := "SELECT * FROM " + table
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if len(order) > 0 {
+		query += " ORDER BY " + d.OrderBy(order)
+	}
+	query += " LIMIT " + strconv.Itoa(limit)
+	if preview.Offset > 0 {
+		query += " OFFSET " + strconv.Itoa(preview.Offset)
+	}
+	// The rows before a key are read from the nearest one back, and sorted forward again.
+	if backward {
+		for i := range order {
+			order[i].Descending = false
+		}
+		query = "SELECT * FROM (" + query + ") AS page ORDER BY " + d.OrderBy(order)
+	}
+
+	return query
+}
+
+// rowValue returns the quoted columns, or else the values, as a row value: parenthesized when there are several.
+func (d Dialect) rowValue(columns []string, values []*string) string {
+	var items []string
+	for _, c := range columns {
+		items = append(items, d.QuoteIdent(c))
+	}
+	for _, v := range values {
+		items = append(items, d.Value(v))
+	}
+	if len(items) == 1 {
+		return items[0]
+	}
+
+	return "(" + strings.Join(items, ", ") + ")"
+}
+
+// OrderBy returns the sort columns as the list of an ORDER BY clause, e.g. "name" DESC, "id".
+func (d Dialect) OrderBy(orders []internal.Order) string {
+	terms := make([]string, len(orders))
+	for i, o := range orders {
+		terms[i] = d.QuoteIdent(o.Column)
+		if o.Descending {
+			terms[i] += " DESC"
+		}
+	}
+
+	return strings.Join(terms, ", ")
+}
+
+// Operators of the conditions built by Condition.
+const (
+	Equal    = "="
+	NotEqual = "<>"
+	Like     = "LIKE"
+)
+
+// likeEscape escapes the wildcards of the patterns built by Condition. Unlike a backslash, it means the same in the
+// string literals of every dialect.
+const likeEscape = "!"
+
+var likeEscaper = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+
+// Condition returns a condition comparing the column to the value with the operator: Equal and NotEqual test for
+// NULL when the value is nil, and Like matches the value anywhere in the column, wildcards in it included.
+func (d Dialect) Condition(column, operator string, value *string) string {
+	column = d.QuoteIdent(column)
+	switch {
+	case value == nil && operator == NotEqual:
+		return column + " IS NOT NULL"
+	case value == nil:
+		return column + " IS NULL"
+	case operator == Like:
+		return column + " LIKE " + d.Quote("%"+likeEscaper.Replace(*value)+"%") + " ESCAPE " + d.Quote(likeEscape)
+	default:
+		return column + " " + operator + " " + d.Quote(*value)
+	}
+}
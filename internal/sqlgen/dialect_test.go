@@ -0,0 +1,112 @@
+package sqlgen
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sptr(s string) *string {
+	return &s
+}
+
+func TestDialect_Quoting(t *testing.T) {
+	pg, my := DialectFor("postgresql"), DialectFor("mysql")
+
+	assert.Equal(t, `"order ""items"""`, pg.QuoteIdent(`order "items"`))
+	assert.Equal(t, "`order ``items```", my.QuoteIdent("order `items`"))
+	assert.Equal(t, `"public"."users"`, pg.QuoteTable("public", "users"))
+	assert.Equal(t, `"users"`, DialectFor("sqlite").QuoteTable("", "users"))
+
+	assert.Equal(t, `'O''Brien \n'`, pg.Quote(`O'Brien \n`))
+	assert.Equal(t, `'O''Brien \\n'`, my.Quote(`O'Brien \n`))
+}
+
+func TestDialect_Literal(t *testing.T) {
+	d := DialectFor("sqlite")
+	for value, want := range map[string]string{
+		"42": "42", "-1.5e3": "-1.5e3", ".5": ".5", "0x10": "'0x10'", "12 ": "'12 '", "Inf": "'Inf'", "": "''",
+	} {
+		assert.Equal(t, want, d.Literal(value), value)
+	}
+	assert.Equal(t, "NULL", d.Value(nil))
+	assert.Equal(t, "'007'", d.Value(sptr("007")), "values read from the database are always quoted")
+}
+
+func TestDialect_Insert(t *testing.T) {
+	d := DialectFor("mysql")
+	assert.Equal(t, "INSERT INTO `t` (`id`, `name`) VALUES ('1', NULL);",
+		d.Insert(d.QuoteIdent("t"), []string{"id", "name"}, []*string{sptr("1"), nil}))
+}
+
+func TestDialect_Update(t *testing.T) {
+	pg := DialectFor("postgresql")
+	s := pg.Update(`"users"`, []string{"name"}, []*string{nil}, []string{"org", "id"}, []*string{sptr("a"), sptr("7")})
+	assert.Equal(t, `UPDATE "users" SET "name" = $1 WHERE "org" = $2 AND "id" = $3`, s.SQL)
+	assert.Equal(t, []any{nil, "a", "7"}, s.Args)
+	assert.Equal(t, "UPDATE \"users\" SET \"name\" = $1 WHERE \"org\" = $2 AND \"id\" = $3;\n-- 1: NULL\n-- 2: 'a'\n-- 3: '7'",
+		pg.Describe(s))
+
+	my := DialectFor("mysql")
+	s = my.Update("`users`", []string{"name"}, []*string{sptr("b")}, []string{"id"}, []*string{sptr("7")})
+	assert.Equal(t, "UPDATE `users` SET `name` = ? WHERE `id` = ?", s.SQL)
+}
+
+func TestDialect_InsertRow(t *testing.T) {
+	pg, my := DialectFor("postgresql"), DialectFor("mysql")
+
+	s := pg.InsertRow(`"users"`, []string{"id", "name"}, []*string{sptr("7"), nil})
+	assert.Equal(t, `INSERT INTO "users" ("id", "name") VALUES ($1, $2)`, s.SQL)
+	assert.Equal(t, []any{"7", nil}, s.Args)
+
+	assert.Equal(t, `INSERT INTO "users" DEFAULT VALUES`, pg.InsertRow(`"users"`, nil, nil).SQL)
+	assert.Equal(t, "INSERT INTO `users` () VALUES ()", my.InsertRow("`users`", nil, nil).SQL)
+}
+
+func TestDialect_Delete(t *testing.T) {
+	s := DialectFor("sqlite").Delete(`"users"`, []string{"org", "id"}, []*string{sptr("a"), sptr("7")})
+	assert.Equal(t, `DELETE FROM "users" WHERE "org" = ? AND "id" = ?`, s.SQL)
+	assert.Equal(t, []any{"a", "7"}, s.Args)
+}
+
+func TestDialect_Select(t *testing.T) {
+	pg, my := DialectFor("postgresql"), DialectFor("mysql")
+
+	assert.Equal(t, `SELECT * FROM "users" LIMIT 50`, pg.Select(`"users"`, internal.Preview{}, 50))
+	assert.Equal(t, "SELECT * FROM `users` WHERE (age > 18 OR admin) ORDER BY `name` DESC, `id` LIMIT 10",
+		my.Select("`users`", internal.Preview{
+			Where:   "age > 18 OR admin",
+			OrderBy: []internal.Order{{Column: "name", Descending: true}, {Column: "id"}},
+		}, 10))
+}
+
+func TestDialect_Condition(t *testing.T) {
+	pg := DialectFor("postgresql")
+
+	assert.Equal(t, `"name" = 'O''Brien'`, pg.Condition("name", Equal, sptr("O'Brien")))
+	assert.Equal(t, `"name" <> '7'`, pg.Condition("name", NotEqual, sptr("7")))
+	assert.Equal(t, `"name" LIKE '%an%' ESCAPE '!'`, pg.Condition("name", Like, sptr("an")))
+	assert.Equal(t, `"name" LIKE '%50!%%' ESCAPE '!'`, pg.Condition("name", Like, sptr("50%")))
+	assert.Equal(t, `"name" LIKE '%a!_b!!%' ESCAPE '!'`, pg.Condition("name", Like, sptr("a_b!")))
+	assert.Equal(t, `"name" IS NULL`, pg.Condition("name", Equal, nil))
+	assert.Equal(t, `"name" IS NOT NULL`, pg.Condition("name", NotEqual, nil))
+
+	mysql := DialectFor("mysql")
+	assert.Equal(t, "`path` LIKE '%C:\\\\tmp!_1%' ESCAPE '!'", mysql.Condition("path", Like, sptr(`C:\tmp_1`)))
+}
+
+func TestDialect_SelectPage(t *testing.T) {
+	pg := DialectFor("postgresql")
+
+	assert.Equal(t, `SELECT * FROM "users" ORDER BY "id" LIMIT 20`,
+		pg.Select(`"users"`, internal.Preview{Limit: 20, Key: []string{"id"}}, 50))
+	assert.Equal(t, `SELECT * FROM "users" WHERE (age > 18) AND "id" > '40' ORDER BY "id" LIMIT 20`,
+		pg.Select(`"users"`, internal.Preview{Where: "age > 18", Limit: 20, Key: []string{"id"}, After: []*string{sptr("40")}}, 50))
+	assert.Equal(t, `SELECT * FROM (SELECT * FROM "users" WHERE ("org", "id") < ('a', '21') ORDER BY "org" DESC, "id" DESC LIMIT 50) AS page ORDER BY "org", "id"`,
+		pg.Select(`"users"`, internal.Preview{Key: []string{"org", "id"}, Before: []*string{sptr("a"), sptr("21")}}, 50))
+	assert.Equal(t, `SELECT * FROM "users" ORDER BY "name" LIMIT 50 OFFSET 100`,
+		pg.Select(`"users"`, internal.Preview{OrderBy: []internal.Order{{Column: "name"}}, Key: []string{"id"}, After: []*string{sptr("1")}, Offset: 100}, 50),
+		"rows sorted by other columns are paged by offset")
+}
@@ -3,16 +3,23 @@ package sqlite
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/sqlgen"
 	_ "modernc.org/sqlite" // import SQLite driver.
 )
 
+var dialect = sqlgen.DialectFor("sqlite")
+
 // DataSource wraps a SQLite DataSource.
 type DataSource struct {
-	db *sql.DB
+	db      *sql.DB
+	session internal.Session
 }
 
 // New initializes a new SQLite Datasource.
@@ -34,8 +41,19 @@ func New(dsn string) (*DataSource, error) {
 	return &DataSource{db: db}, nil
 }
 
+// ValidateDSN reports whether dsn can name a SQLite database file.
+// The file itself is only checked on connect, as it may legitimately be created later.
+func ValidateDSN(dsn string) error {
+	if strings.TrimSpace(dsn) == "" {
+		return errors.New("empty database file path")
+	}
+
+	return nil
+}
+
+// query runs the query in the session transaction, if one is open.
 func (d *DataSource) query(query string) (data [][]*string, err error) {
-	rows, err := d.db.Query(query)
+	rows, err := d.session.Querier(d.db).Query(query)
 	if err != nil {
 		return
 	}
@@ -78,6 +96,15 @@ func (d *DataSource) Ping() error {
 	return d.db.Ping()
 }
 
+// ServerInfo returns the version of the SQLite library. SQLite has no users; the session is read-only while
+// query_only is set.
+func (d *DataSource) ServerInfo() (info internal.ServerInfo, err error) {
+	info.Engine = "SQLite"
+	err = d.db.QueryRow("SELECT sqlite_version(), (SELECT query_only FROM pragma_query_only)").Scan(&info.Version, &info.ReadOnly)
+
+	return
+}
+
 // ListSchemas returns available schemas.
 func (d *DataSource) ListSchemas() ([]string, error) {
 	return []string{"main"}, nil
@@ -105,9 +132,50 @@ func (d *DataSource) ListTables(_ string) ([]string, error) {
 	return tables, err
 }
 
+// ListViews lists available views in the database.
+func (d *DataSource) ListViews(_ string) ([]string, error) {
+	res, err := d.db.Query("SELECT name FROM sqlite_master WHERE type = 'view' ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseOrLog(res)
+
+	views := []string{}
+	for res.Next() {
+		var view string
+		if err = res.Scan(&view); err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	return views, res.Err()
+}
+
 // PreviewTable returns first 10 row from given table.
-func (d *DataSource) PreviewTable(_, table string) ([][]*string, error) {
-	return d.query(fmt.Sprintf("SELECT * FROM %s LIMIT 10", table))
+func (d *DataSource) PreviewTable(_, table string, preview internal.Preview) ([][]*string, error) {
+	return d.query(dialect.Select(dialect.QuoteIdent(table), preview, 10))
+}
+
+// EstimateRows estimates the rows of the table from the statistics gathered by ANALYZE, or else from the largest
+// rowid, which is exact until rows are deleted.
+func (d *DataSource) EstimateRows(_, table string) (int64, error) {
+	var stat string
+	if d.db.QueryRow("SELECT stat FROM sqlite_stat1 WHERE tbl = ? LIMIT 1", table).Scan(&stat) == nil {
+		if fields := strings.Fields(stat); len(fields) > 0 {
+			if rows, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+				return rows, nil
+			}
+		}
+	}
+
+	var rows sql.NullInt64
+	// Tables without a rowid have no estimate.
+	if d.db.QueryRow("SELECT max(rowid) FROM "+dialect.QuoteIdent(table)).Scan(&rows) != nil {
+		return -1, nil
+	}
+
+	return rows.Int64, nil
 }
 
 // DescribeTable describes table.
@@ -115,7 +183,83 @@ func (d *DataSource) DescribeTable(_, table string) ([][]*string, error) {
 	return d.query(fmt.Sprintf("SELECT sql FROM sqlite_master WHERE name = '%s';", table))
 }
 
+// ListColumns returns the columns of the table.
+func (d *DataSource) ListColumns(_, table string) ([]internal.Column, error) {
+	columns, err := d.listColumns(table)
+	if err != nil {
+		return nil, err
+	}
+	if columns[table] == nil {
+		return []internal.Column{}, nil
+	}
+
+	return columns[table], nil
+}
+
+// ListSchemaColumns returns the columns of every table and view by their name.
+func (d *DataSource) ListSchemaColumns(_ string) (map[string][]internal.Column, error) {
+	return d.listColumns("")
+}
+
+// listColumns reads the columns of the table, or of every table and view when table is empty, by table name.
+func (d *DataSource) listColumns(table string) (map[string][]internal.Column, error) {
+	res, err := d.db.Query(`SELECT m.name, p.name, p.type, p."notnull" = 0, p.pk > 0, p.dflt_value
+		FROM sqlite_master m JOIN pragma_table_info(m.name) p
+		WHERE m.type IN ('table', 'view') AND (?1 = '' OR m.name = ?1)
+		ORDER BY m.name, p.cid`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseOrLog(res)
+
+	columns := map[string][]internal.Column{}
+	for res.Next() {
+		var name string
+		var c internal.Column
+		if err = res.Scan(&name, &c.Name, &c.Type, &c.Nullable, &c.PrimaryKey, &c.Default); err != nil {
+			return nil, err
+		}
+		columns[name] = append(columns[name], c)
+	}
+
+	return columns, res.Err()
+}
+
 // Query executes given query on database.
 func (d *DataSource) Query(_, query string) ([][]*string, error) {
 	return d.query(query)
 }
+
+// Execute runs given statements in a transaction.
+func (d *DataSource) Execute(_ string, statements []internal.Statement) ([]int64, error) {
+	if tx := d.session.Tx(); tx != nil {
+		return internal.ExecuteInSavepoint(tx, statements)
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.ExecuteInTx(tx, statements)
+}
+
+// Begin opens a session transaction.
+func (d *DataSource) Begin() error {
+	return d.session.Begin(d.db)
+}
+
+// Commit commits the session transaction.
+func (d *DataSource) Commit() error {
+	return d.session.Commit()
+}
+
+// Rollback rolls the session transaction back.
+func (d *DataSource) Rollback() error {
+	return d.session.Rollback()
+}
+
+// InTransaction reports whether a session transaction is open.
+func (d *DataSource) InTransaction() bool {
+	return d.session.Active()
+}
@@ -1,8 +1,11 @@
 package sqlite
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/kenanbek/dbui/internal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -59,14 +62,67 @@ func Test_SQLite(t *testing.T) {
 				assert.ElementsMatch(t, tables, []string{"albums", "sqlite_sequence", "artists", "customers", "employees", "genres", "invoices", "invoice_items", "media_types", "playlists", "playlist_track", "tracks", "sqlite_stat1"})
 			},
 		},
+		{
+			name: "list views",
+			do: func(t *testing.T) {
+				views, err := ds.ListViews("")
+
+				assert.NoError(t, err)
+				assert.Empty(t, views)
+			},
+		},
+		{
+			name: "server info",
+			do: func(t *testing.T) {
+				info, err := ds.ServerInfo()
+
+				assert.NoError(t, err)
+				assert.Equal(t, "SQLite", info.Engine)
+				assert.NotEmpty(t, info.Version)
+				assert.Empty(t, info.User)
+				assert.False(t, info.ReadOnly)
+			},
+		},
 		{
 			name: "preview table",
 			do: func(t *testing.T) {
-				albums, err := ds.PreviewTable("", "albums")
+				albums, err := ds.PreviewTable("", "albums", internal.Preview{})
 
 				assert.NoError(t, err)
 				assert.Len(t, albums, 11)
 				assert.Len(t, albums[0], 3)
+
+				albums, err = ds.PreviewTable("", "albums", internal.Preview{
+					Where:   "ArtistId = 1",
+					OrderBy: []internal.Order{{Column: "AlbumId", Descending: true}},
+				})
+				assert.NoError(t, err)
+				assert.Len(t, albums, 3)
+				assert.Equal(t, "4", *albums[1][0])
+
+				albums, err = ds.PreviewTable("", "albums", internal.Preview{Limit: 2, Key: []string{"AlbumId"}, After: []*string{sptr("100")}})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"101", "102"}, []string{*albums[1][0], *albums[2][0]})
+
+				albums, err = ds.PreviewTable("", "albums", internal.Preview{Limit: 2, Key: []string{"AlbumId"}, Before: []*string{sptr("100")}})
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"98", "99"}, []string{*albums[1][0], *albums[2][0]})
+			},
+		},
+		{
+			name: "estimate rows",
+			do: func(t *testing.T) {
+				rows, err := ds.EstimateRows("", "albums")
+				assert.NoError(t, err)
+				assert.EqualValues(t, 347, rows, "from the statistics")
+
+				rows, err = ds.EstimateRows("", "sqlite_sequence")
+				assert.NoError(t, err)
+				assert.EqualValues(t, 10, rows, "from the largest rowid")
+
+				rows, err = ds.EstimateRows("", "no_such_table")
+				assert.NoError(t, err)
+				assert.EqualValues(t, -1, rows)
 			},
 		},
 		{
@@ -79,6 +135,29 @@ func Test_SQLite(t *testing.T) {
 				assert.Contains(t, *table[1][0], `CREATE TABLE "albums"`)
 			},
 		},
+		{
+			name: "list columns",
+			do: func(t *testing.T) {
+				columns, err := ds.ListColumns("", "albums")
+
+				assert.NoError(t, err)
+				assert.Equal(t, []internal.Column{
+					{Name: "AlbumId", Type: "INTEGER", PrimaryKey: true},
+					{Name: "Title", Type: "NVARCHAR(160)"},
+					{Name: "ArtistId", Type: "INTEGER"},
+				}, columns)
+
+				columns, err = ds.ListColumns("", "no_such_table")
+				assert.NoError(t, err)
+				assert.Empty(t, columns)
+
+				all, err := ds.ListSchemaColumns("")
+				assert.NoError(t, err)
+				albums, _ := ds.ListColumns("", "albums")
+				assert.Equal(t, albums, all["albums"])
+				assert.Contains(t, all, "tracks")
+			},
+		},
 		{
 			name: "query table",
 			do: func(t *testing.T) {
@@ -95,3 +174,83 @@ func Test_SQLite(t *testing.T) {
 		})
 	}
 }
+
+func sptr(s string) *string {
+	return &s
+}
+
+func Test_SQLiteExecute(t *testing.T) {
+	data, err := os.ReadFile("testdata/chinook.db")
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "chinook.db")
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	ds, err := New(file)
+	require.NoError(t, err)
+
+	name := func() string {
+		rows, err := ds.Query("main", "SELECT Name FROM artists WHERE ArtistId = 1")
+		require.NoError(t, err)
+		return *rows[1][0]
+	}
+
+	affected, err := ds.Execute("main", []internal.Statement{
+		{SQL: "UPDATE artists SET Name = ? WHERE ArtistId = ?", Args: []any{"AC-DC", "1"}},
+		{SQL: "UPDATE artists SET Name = ? WHERE ArtistId > ?", Args: []any{"Various", "1000"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 0}, affected)
+	assert.Equal(t, "AC-DC", name())
+
+	_, err = ds.Execute("main", []internal.Statement{
+		{SQL: "UPDATE artists SET Name = ? WHERE ArtistId = ?", Args: []any{"AC/DC", "1"}},
+		{SQL: "UPDATE no_such_table SET Name = NULL"},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "AC-DC", name(), "the failed transaction is rolled back")
+}
+
+func Test_SQLiteTransaction(t *testing.T) {
+	data, err := os.ReadFile("testdata/chinook.db")
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "chinook.db")
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	ds, err := New(file)
+	require.NoError(t, err)
+
+	name := func() string {
+		rows, err := ds.Query("main", "SELECT Name FROM artists WHERE ArtistId = 1")
+		require.NoError(t, err)
+		return *rows[1][0]
+	}
+	assert.False(t, ds.InTransaction())
+	assert.ErrorIs(t, ds.Commit(), internal.ErrNoTransaction)
+
+	require.NoError(t, ds.Begin())
+	assert.True(t, ds.InTransaction())
+	assert.ErrorIs(t, ds.Begin(), internal.ErrTransactionOpen)
+
+	_, err = ds.Query("main", "UPDATE artists SET Name = 'AC-DC' WHERE ArtistId = 1")
+	require.NoError(t, err)
+	assert.Equal(t, "AC-DC", name(), "queries see the uncommitted changes")
+
+	_, err = ds.Execute("main", []internal.Statement{
+		{SQL: "UPDATE artists SET Name = ? WHERE ArtistId = ?", Args: []any{"AC/DC", "1"}},
+		{SQL: "UPDATE no_such_table SET Name = NULL"},
+	})
+	assert.Error(t, err)
+	assert.True(t, ds.InTransaction(), "a failed execution keeps the transaction open")
+	assert.Equal(t, "AC-DC", name(), "only the failed execution is rolled back")
+
+	require.NoError(t, ds.Rollback())
+	assert.False(t, ds.InTransaction())
+	assert.Equal(t, "AC/DC", name(), "the rollback restores the committed value")
+
+	require.NoError(t, ds.Begin())
+	affected, err := ds.Execute("main", []internal.Statement{{SQL: "UPDATE artists SET Name = ? WHERE ArtistId = ?", Args: []any{"ACDC", "1"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, affected)
+	require.NoError(t, ds.Commit())
+	assert.Equal(t, "ACDC", name())
+}
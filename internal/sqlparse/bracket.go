@@ -0,0 +1,52 @@
+package sqlparse
+
+var bracketPairs = map[string]string{"(": ")", "[": "]", "{": "}"}
+
+// MatchBracket returns the offset of the bracket matching the one starting at the given offset.
+// Brackets inside strings, quoted identifiers and comments are ignored.
+func MatchBracket(tokens []Token, offset int) (int, bool) {
+	i := -1
+	for j, t := range tokens {
+		if t.Start == offset && t.Kind == Punctuation {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return 0, false
+	}
+
+	open := tokens[i].Text
+	if closing, ok := bracketPairs[open]; ok {
+		return scanBracket(tokens, i, 1, open, closing)
+	}
+	for opening, closing := range bracketPairs {
+		if closing == open {
+			return scanBracket(tokens, i, -1, closing, opening)
+		}
+	}
+
+	return 0, false
+}
+
+// scanBracket walks the tokens from i in the given direction until the bracket depth drops to zero.
+func scanBracket(tokens []Token, i, direction int, same, partner string) (int, bool) {
+	depth := 0
+	for ; i >= 0 && i < len(tokens); i += direction {
+		t := tokens[i]
+		if t.Kind != Punctuation {
+			continue
+		}
+		switch t.Text {
+		case same:
+			depth++
+		case partner:
+			depth--
+			if depth == 0 {
+				return t.Start, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,158 @@
+package sqlparse
+
+import "strings"
+
+// Class names a kind of statement which changes or destroys data wholesale, so that running one can be confirmed
+// first.
+type Class string
+
+const (
+	// DeleteAll is a DELETE without a WHERE clause.
+	DeleteAll Class = "delete"
+	// UpdateAll is an UPDATE without a WHERE clause.
+	UpdateAll Class = "update"
+	// Drop is any DROP statement.
+	Drop Class = "drop"
+	// Truncate is a TRUNCATE statement.
+	Truncate Class = "truncate"
+	// Alter is any ALTER statement.
+	Alter Class = "alter"
+)
+
+// Classes lists every class.
+var Classes = []Class{DeleteAll, UpdateAll, Drop, Truncate, Alter}
+
+// Classification is the class of a statement and the table it acts on.
+type Classification struct {
+	// Class is empty for statements of no class.
+	Class Class
+	// Table is the name of the table as written in the statement, qualified and quoted the same way, or empty when
+	// the statement does not act on a table.
+	Table string
+	// Schema and Name are the qualifier and the name of the table without quotes, for looking the table up. Schema
+	// is empty when the name is not qualified.
+	Schema, Name string
+}
+
+// Classify finds the class of a single statement and the table it acts on. It only looks at the leading keywords and
+// at the clauses outside of brackets, so statements starting with a WITH clause are of no class.
+func Classify(statement string) Classification {
+	var tokens []Token
+	for _, t := range Tokenize(statement) {
+		if t.Significant() {
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) == 0 {
+		return Classification{}
+	}
+
+	rest := tokens[1:]
+	switch strings.ToUpper(tokens[0].Text) {
+	case "DELETE":
+		if indexWord(rest, "WHERE") >= 0 {
+			return Classification{}
+		}
+		if from := indexWord(rest, "FROM"); from >= 0 {
+			rest = rest[from+1:]
+		}
+		return acting(DeleteAll, skipWords(rest, "ONLY"))
+	case "UPDATE":
+		if indexWord(rest, "WHERE") >= 0 {
+			return Classification{}
+		}
+		return acting(UpdateAll, skipWords(rest, "LOW_PRIORITY", "IGNORE", "ONLY"))
+	case "DROP":
+		return acting(Drop, tableOf(rest))
+	case "TRUNCATE":
+		return acting(Truncate, skipWords(rest, "TABLE", "ONLY"))
+	case "ALTER":
+		return acting(Alter, tableOf(rest))
+	default:
+		return Classification{}
+	}
+}
+
+// tableOf returns the tokens following TABLE [IF EXISTS] [ONLY], or none when other objects follow.
+func tableOf(tokens []Token) []Token {
+	if len(tokens) == 0 || !isWord(tokens[0], "TABLE") {
+		return nil
+	}
+	return skipWords(tokens[1:], "IF", "EXISTS", "ONLY")
+}
+
+// acting returns the class of a statement acting on the table named by the leading tokens together with its
+// qualifiers, e.g. `public."Users"`.
+func acting(class Class, tokens []Token) Classification {
+	c := Classification{Class: class}
+	var table strings.Builder
+	var parts []string
+	for i, t := range tokens {
+		dot := t.Kind == Punctuation && t.Text == "."
+		if i%2 == 1 && !dot || i%2 == 0 && !isName(t) {
+			break
+		}
+		table.WriteString(t.Text)
+		if !dot {
+			parts = append(parts, Unquote(t.Text))
+		}
+	}
+
+	c.Table = strings.TrimSuffix(table.String(), ".")
+	if n := len(parts); n > 0 {
+		c.Name = parts[n-1]
+		if n > 1 {
+			c.Schema = parts[n-2]
+		}
+	}
+	return c
+}
+
+// Unquote returns the name without the double quotes or backticks around it.
+func Unquote(name string) string {
+	if len(name) >= 2 && (name[0] == '"' || name[0] == '`') && name[len(name)-1] == name[0] {
+		return name[1 : len(name)-1]
+	}
+	return name
+}
+
+// indexWord returns the index of the first word outside of brackets, or -1.
+func indexWord(tokens []Token, word string) int {
+	depth := 0
+	for i, t := range tokens {
+		switch {
+		case t.Kind == Punctuation && t.Text == "(":
+			depth++
+		case t.Kind == Punctuation && t.Text == ")":
+			depth--
+		case depth == 0 && isWord(t, word):
+			return i
+		}
+	}
+	return -1
+}
+
+// skipWords drops the leading tokens which are any of the words.
+func skipWords(tokens []Token, words ...string) []Token {
+	for len(tokens) > 0 {
+		skip := false
+		for _, w := range words {
+			skip = skip || isWord(tokens[0], w)
+		}
+		if !skip {
+			break
+		}
+		tokens = tokens[1:]
+	}
+	return tokens
+}
+
+// isWord reports whether the token is the unquoted word, in any case.
+func isWord(t Token, word string) bool {
+	return (t.Kind == Keyword || t.Kind == Identifier) && strings.EqualFold(t.Text, word)
+}
+
+// isName reports whether the token can name a table.
+func isName(t Token) bool {
+	return t.Kind == Identifier || t.Kind == QuotedIdentifier || t.Kind == Keyword
+}
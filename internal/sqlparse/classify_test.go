@@ -0,0 +1,34 @@
+package sqlparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	for _, tt := range []struct {
+		statement string
+		want      Classification
+	}{
+		{"SELECT * FROM users", Classification{}},
+		{"", Classification{}},
+		{"DELETE FROM users WHERE id = 1", Classification{}},
+		{"-- purge\ndelete from public.\"Users\"", Classification{Class: DeleteAll, Table: `public."Users"`, Schema: "public", Name: "Users"}},
+		{"DELETE FROM users WHERE id IN (SELECT id FROM banned)", Classification{}},
+		{"DELETE FROM users u USING banned b", Classification{Class: DeleteAll, Table: "users", Name: "users"}},
+		{"UPDATE users SET name = 'x'", Classification{Class: UpdateAll, Table: "users", Name: "users"}},
+		{"UPDATE LOW_PRIORITY `shop`.`users` SET name = 'where'", Classification{Class: UpdateAll, Table: "`shop`.`users`", Schema: "shop", Name: "users"}},
+		{"UPDATE users SET score = (SELECT max(score) FROM scores WHERE scores.id = users.id)", Classification{Class: UpdateAll, Table: "users", Name: "users"}},
+		{"UPDATE users SET name = 'x' WHERE id = 1", Classification{}},
+		{"DROP TABLE IF EXISTS users CASCADE", Classification{Class: Drop, Table: "users", Name: "users"}},
+		{"DROP INDEX users_name", Classification{Class: Drop}},
+		{"TRUNCATE TABLE ONLY users", Classification{Class: Truncate, Table: "users", Name: "users"}},
+		{"truncate users", Classification{Class: Truncate, Table: "users", Name: "users"}},
+		{"ALTER TABLE users ADD COLUMN age int", Classification{Class: Alter, Table: "users", Name: "users"}},
+		{"ALTER USER bob WITH PASSWORD 'x'", Classification{Class: Alter}},
+		{"WITH gone AS (SELECT 1) DELETE FROM users", Classification{}},
+	} {
+		assert.Equal(t, tt.want, Classify(tt.statement), tt.statement)
+	}
+}
@@ -0,0 +1,42 @@
+package sqlparse
+
+import "strings"
+
+// Keywords lists the SQL keywords recognized by the lexer, common to MySQL, PostgreSQL and SQLite.
+var Keywords = []string{
+	"ADD", "ALL", "ALTER", "AND", "ANY", "AS", "ASC", "AUTO_INCREMENT",
+	"BEGIN", "BETWEEN", "BY",
+	"CASCADE", "CASE", "CAST", "CHECK", "COLLATE", "COLUMN", "COMMIT", "CONSTRAINT", "CREATE", "CROSS", "CURRENT_DATE",
+	"CURRENT_TIME", "CURRENT_TIMESTAMP",
+	"DATABASE", "DEFAULT", "DELETE", "DESC", "DESCRIBE", "DISTINCT", "DROP",
+	"ELSE", "END", "ESCAPE", "EXCEPT", "EXISTS", "EXPLAIN",
+	"FALSE", "FETCH", "FIRST", "FOR", "FOREIGN", "FROM", "FULL",
+	"GRANT", "GROUP",
+	"HAVING",
+	"IF", "ILIKE", "IN", "INDEX", "INNER", "INSERT", "INTERSECT", "INTO", "IS",
+	"JOIN",
+	"KEY",
+	"LAST", "LEFT", "LIKE", "LIMIT",
+	"NATURAL", "NOT", "NULL", "NULLS",
+	"OFFSET", "ON", "OR", "ORDER", "OUTER", "OVER",
+	"PARTITION", "PRIMARY",
+	"RECURSIVE", "REFERENCES", "RENAME", "REPLACE", "RETURNING", "REVOKE", "RIGHT", "ROLLBACK", "ROW", "ROWS",
+	"SAVEPOINT", "SCHEMA", "SELECT", "SET", "SHOW", "START",
+	"TABLE", "TEMPORARY", "THEN", "TO", "TRANSACTION", "TRIGGER", "TRUE", "TRUNCATE",
+	"UNION", "UNIQUE", "UPDATE", "USE", "USING",
+	"VALUES", "VIEW",
+	"WHEN", "WHERE", "WINDOW", "WITH",
+}
+
+var keywordSet = func() map[string]bool {
+	set := make(map[string]bool, len(Keywords))
+	for _, k := range Keywords {
+		set[k] = true
+	}
+	return set
+}()
+
+// IsKeyword reports whether the word is an SQL keyword, regardless of its case.
+func IsKeyword(word string) bool {
+	return keywordSet[strings.ToUpper(word)]
+}
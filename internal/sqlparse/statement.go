@@ -0,0 +1,90 @@
+package sqlparse
+
+import "strings"
+
+// Statement is a single SQL statement of a script. Start and End are the byte offsets of Text in the script;
+// the text is trimmed and does not include the terminating semicolon.
+type Statement struct {
+	Text       string
+	Start, End int
+}
+
+// Split splits a script into statements on semicolons outside of strings, quoted identifiers and comments.
+// Statements made of whitespace and comments only are dropped.
+func Split(src string) []Statement {
+	var statements []Statement
+	for _, s := range segments(src, Tokenize(src)) {
+		if s.significant {
+			statements = append(statements, s.Statement)
+		}
+	}
+
+	return statements
+}
+
+// StatementAt returns the statement at the given byte offset of the script. When the offset is
+// between statements, e.g. right after a semicolon, the statement before it is returned.
+func StatementAt(src string, offset int) (Statement, bool) {
+	var prev *Statement
+	for _, s := range segments(src, Tokenize(src)) {
+		if offset <= s.until {
+			// Blank segments and the rest of the line closing the previous statement belong to it.
+			leading := offset <= s.Start && !strings.Contains(src[s.from:offset], "\n")
+			if prev != nil && (!s.significant || leading) {
+				return *prev, true
+			}
+			if s.significant {
+				return s.Statement, true
+			}
+		}
+		if s.significant {
+			prev = &s.Statement
+		}
+	}
+	if prev != nil {
+		return *prev, true
+	}
+
+	return Statement{}, false
+}
+
+type segment struct {
+	Statement
+	// from is the offset following the previous semicolon and until the offset of the semicolon
+	// closing the segment, or the end of the script.
+	from, until int
+	significant bool
+}
+
+func segments(src string, tokens []Token) []segment {
+	var (
+		result      []segment
+		start       int
+		significant bool
+	)
+	flush := func(until int) {
+		text := src[start:until]
+		trimmed := strings.TrimSpace(text)
+		offset := start + strings.Index(text, trimmed)
+		result = append(result, segment{
+			Statement:   Statement{Text: trimmed, Start: offset, End: offset + len(trimmed)},
+			from:        start,
+			until:       until,
+			significant: significant,
+		})
+	}
+
+	for _, t := range tokens {
+		if t.Kind == Punctuation && t.Text == ";" {
+			flush(t.Start)
+			start, significant = t.End, false
+			continue
+		}
+		if t.Significant() {
+			significant = true
+		}
+	}
+	flush(len(src))
+
+	return result
+}
@@ -0,0 +1,53 @@
+package sqlparse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const script = `-- employees
+SELECT * FROM employees WHERE note = 'a;b';
+
+  UPDATE salaries SET amount = 1; -- done
+;
+/* last */ DELETE FROM t`
+
+func TestSplit(t *testing.T) {
+	var got []string
+	for _, s := range Split(script) {
+		assert.Equal(t, s.Text, script[s.Start:s.End])
+		got = append(got, s.Text)
+	}
+	assert.Equal(t, []string{
+		"-- employees\nSELECT * FROM employees WHERE note = 'a;b'",
+		"UPDATE salaries SET amount = 1",
+		"/* last */ DELETE FROM t",
+	}, got, "comment only statements are dropped")
+
+	assert.Empty(t, Split(" ; -- nothing\n ;"))
+}
+
+func TestStatementAt(t *testing.T) {
+	at := func(marker string) string {
+		s, ok := StatementAt(script, strings.Index(script, marker))
+		assert.True(t, ok, marker)
+		return s.Text
+	}
+
+	assert.Contains(t, at("employees WHERE"), "SELECT")
+	assert.Contains(t, at(";\n\n  UPDATE"), "SELECT", "cursor right before the semicolon")
+	assert.Contains(t, at("\n\n  UPDATE"), "SELECT", "rest of the line after the semicolon")
+	assert.Contains(t, at("  UPDATE"), "UPDATE", "leading whitespace on the next line")
+	assert.Contains(t, at(" -- done"), "UPDATE")
+	assert.Contains(t, at("\n/* last */"), "UPDATE", "blank statement belongs to the previous one")
+	assert.Contains(t, at("DELETE"), "DELETE")
+
+	s, ok := StatementAt(script, len(script))
+	assert.True(t, ok)
+	assert.Equal(t, "/* last */ DELETE FROM t", s.Text)
+
+	_, ok = StatementAt("  -- nothing", 3)
+	assert.False(t, ok)
+}
@@ -0,0 +1,227 @@
+// Package sqlparse implements a lightweight, dialect tolerant SQL lexer.
+// It is not a parser: it knows enough about strings, comments and quoting
+// to highlight SQL text, split scripts into statements, find brackets and
+// tell statements changing data wholesale.
+package sqlparse
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind classifies a token.
+type TokenKind int
+
+const (
+	// Whitespace is a run of spaces, tabs and line breaks.
+	Whitespace TokenKind = iota
+	// Comment is a `-- line` or a `/* block */` comment.
+	Comment
+	// Keyword is a reserved SQL word, e.g. SELECT or FROM.
+	Keyword
+	// Identifier is an unquoted name of a schema, table, column, function, etc.
+	Identifier
+	// QuotedIdentifier is a name in double quotes or backticks.
+	QuotedIdentifier
+	// String is a string literal in single quotes, including E'' and $$ dollar quoted strings.
+	String
+	// Number is a numeric literal.
+	Number
+	// Parameter is a bind parameter or a variable: `?`, `$1`, `:name` or `@name`.
+	Parameter
+	// Punctuation is one of `( ) [ ] { } , ; .`.
+	Punctuation
+	// Operator is any other symbol, e.g. `=`, `<>` or `::`.
+	Operator
+)
+
+// Token is a lexical unit of SQL text. Start and End are byte offsets into the tokenized text.
+type Token struct {
+	Kind       TokenKind
+	Text       string
+	Start, End int
+}
+
+// Tokenize splits SQL text into tokens. It never fails: unterminated strings and comments extend to the end of the text.
+// Concatenating the text of all tokens yields the input.
+func Tokenize(src string) []Token {
+	var tokens []Token
+	for pos := 0; pos < len(src); {
+		kind, end := scan(src, pos)
+		tokens = append(tokens, Token{Kind: kind, Text: src[pos:end], Start: pos, End: end})
+		pos = end
+	}
+
+	return tokens
+}
+
+func scan(src string, pos int) (TokenKind, int) {
+	r, size := utf8.DecodeRuneInString(src[pos:])
+	rest := src[pos:]
+
+	switch {
+	case unicode.IsSpace(r):
+		end := pos + size
+		for end < len(src) {
+			r, size := utf8.DecodeRuneInString(src[end:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			end += size
+		}
+		return Whitespace, end
+	case strings.HasPrefix(rest, "--"):
+		if i := strings.IndexByte(rest, '\n'); i >= 0 {
+			return Comment, pos + i
+		}
+		return Comment, len(src)
+	case strings.HasPrefix(rest, "/*"):
+		if i := strings.Index(rest[2:], "*/"); i >= 0 {
+			return Comment, pos + 2 + i + 2
+		}
+		return Comment, len(src)
+	case r == '\'':
+		return String, scanQuoted(src, pos, '\'', false)
+	case (r == 'E' || r == 'e') && strings.HasPrefix(rest[1:], "'"):
+		return String, scanQuoted(src, pos+1, '\'', true)
+	case r == '"' || r == '`':
+		return QuotedIdentifier, scanQuoted(src, pos, byte(r), false)
+	case r == '$':
+		if end, ok := scanDollarQuoted(src, pos); ok {
+			return String, end
+		}
+		end := pos + 1
+		for end < len(src) && isDigit(src[end]) {
+			end++
+		}
+		if end > pos+1 {
+			return Parameter, end
+		}
+		return Operator, end
+	case r == '?':
+		return Parameter, pos + 1
+	case (r == ':' || r == '@') && pos+1 < len(src):
+		// `::` is a cast and `:=` an assignment, not parameters.
+		next, _ := utf8.DecodeRuneInString(src[pos+1:])
+		if isIdentStart(next) && (pos == 0 || src[pos-1] != ':') {
+			return Parameter, scanWord(src, pos+1)
+		}
+	case '0' <= r && r <= '9' || r == '.' && pos+1 < len(src) && isDigit(src[pos+1]):
+		return Number, scanNumber(src, pos)
+	case isIdentStart(r):
+		end := scanWord(src, pos)
+		if IsKeyword(src[pos:end]) {
+			return Keyword, end
+		}
+		return Identifier, end
+	case strings.ContainsRune("()[]{},;.", r):
+		return Punctuation, pos + 1
+	}
+
+	// Operators are made of consecutive symbols, except those starting another token.
+	end := pos + size
+	for end < len(src) {
+		r, size := utf8.DecodeRuneInString(src[end:])
+		if !strings.ContainsRune("+-*/<>=~!%^&|:#", r) || strings.HasPrefix(src[end:], "--") || strings.HasPrefix(src[end:], "/*") {
+			break
+		}
+		end += size
+	}
+	return Operator, end
+}
+
+// scanQuoted returns the end of a quoted text starting at pos. A doubled quote escapes the quote,
+// and so does a backslash when backslashes are escapes.
+func scanQuoted(src string, pos int, quote byte, backslash bool) int {
+	for i := pos + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(src) && src[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+
+	return len(src)
+}
+
+// scanDollarQuoted scans a PostgreSQL $tag$...$tag$ string.
+func scanDollarQuoted(src string, pos int) (int, bool) {
+	i := pos + 1
+	for i < len(src) && (src[i] == '_' || isLetter(src[i]) || i > pos+1 && isDigit(src[i])) {
+		i++
+	}
+	if i >= len(src) || src[i] != '$' {
+		return 0, false
+	}
+
+	tag := src[pos : i+1]
+	if end := strings.Index(src[i+1:], tag); end >= 0 {
+		return i + 1 + end + len(tag), true
+	}
+	return len(src), true
+}
+
+func scanWord(src string, pos int) int {
+	end := pos
+	for end < len(src) {
+		r, size := utf8.DecodeRuneInString(src[end:])
+		if !isIdentStart(r) && !unicode.IsDigit(r) && r != '$' {
+			break
+		}
+		end += size
+	}
+
+	return end
+}
+
+func scanNumber(src string, pos int) int {
+	end := pos
+	for end < len(src) && isDigit(src[end]) {
+		end++
+	}
+	if end < len(src) && src[end] == '.' {
+		end++
+		for end < len(src) && isDigit(src[end]) {
+			end++
+		}
+	}
+	if end+1 < len(src) && (src[end] == 'e' || src[end] == 'E') {
+		exp := end + 1
+		if src[exp] == '+' || src[exp] == '-' {
+			exp++
+		}
+		if exp < len(src) && isDigit(src[exp]) {
+			end = exp
+			for end < len(src) && isDigit(src[end]) {
+				end++
+			}
+		}
+	}
+
+	return end
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isLetter(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// Significant reports whether the token carries meaning, i.e. it is neither whitespace nor a comment.
+func (t Token) Significant() bool {
+	return t.Kind != Whitespace && t.Kind != Comment
+}
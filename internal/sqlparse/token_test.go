@@ -0,0 +1,69 @@
+package sqlparse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	src := "SELECT e.name, 'it''s; fine' AS \"x;y\", 1.5e3 -- trailing; comment\n" +
+		"FROM `emp` e /* block; */ WHERE id = :id AND n::int > $1 AND b = ? AND s = E'a\\'b' AND d = $$x;y$$"
+
+	var got []string
+	var text strings.Builder
+	for _, tok := range Tokenize(src) {
+		text.WriteString(tok.Text)
+		assert.Equal(t, tok.Text, src[tok.Start:tok.End])
+		if tok.Significant() || tok.Kind == Comment {
+			got = append(got, kindNames[tok.Kind]+":"+tok.Text)
+		}
+	}
+	assert.Equal(t, src, text.String(), "tokens cover the whole input")
+	assert.Equal(t, []string{
+		"keyword:SELECT", "ident:e", "punct:.", "ident:name", "punct:,", "string:'it''s; fine'", "keyword:AS",
+		`quoted:"x;y"`, "punct:,", "number:1.5e3", "comment:-- trailing; comment",
+		"keyword:FROM", "quoted:`emp`", "ident:e", "comment:/* block; */", "keyword:WHERE", "ident:id", "op:=",
+		"param::id", "keyword:AND", "ident:n", "op:::", "ident:int", "op:>", "param:$1", "keyword:AND", "ident:b",
+		"op:=", "param:?", "keyword:AND", "ident:s", "op:=", `string:E'a\'b'`, "keyword:AND", "ident:d", "op:=",
+		"string:$$x;y$$",
+	}, got)
+}
+
+func TestTokenize_Unterminated(t *testing.T) {
+	for _, src := range []string{"select 'abc", "select /* abc", `select "abc`, "select $$abc"} {
+		tokens := Tokenize(src)
+		last := tokens[len(tokens)-1]
+		assert.Equal(t, len(src), last.End, src)
+		assert.NotEqual(t, Identifier, last.Kind, src)
+	}
+}
+
+func TestMatchBracket(t *testing.T) {
+	src := "count((a + ')') * [b])"
+	tokens := Tokenize(src)
+
+	at, ok := MatchBracket(tokens, 5)
+	assert.True(t, ok)
+	assert.Equal(t, len(src)-1, at)
+
+	at, ok = MatchBracket(tokens, len(src)-1)
+	assert.True(t, ok)
+	assert.Equal(t, 5, at)
+
+	at, ok = MatchBracket(tokens, strings.Index(src, "]"))
+	assert.True(t, ok)
+	assert.Equal(t, strings.Index(src, "["), at)
+
+	_, ok = MatchBracket(tokens, strings.Index(src, "')'")+1)
+	assert.False(t, ok, "brackets in strings are ignored")
+
+	_, ok = MatchBracket(Tokenize("(()"), 0)
+	assert.False(t, ok)
+}
+
+var kindNames = map[TokenKind]string{
+	Comment: "comment", Keyword: "keyword", Identifier: "ident", QuotedIdentifier: "quoted", String: "string",
+	Number: "number", Parameter: "param", Punctuation: "punct", Operator: "op",
+}
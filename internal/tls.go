@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrIncompleteKeyPair indicates that only one of the client certificate and key files is configured.
+var ErrIncompleteKeyPair = errors.New("tls: cert and key files must be set together")
+
+// NewTLSConfig builds a *tls.Config out of the provided data source TLS settings.
+func NewTLSConfig(c TLSConfig) (*tls.Config, error) {
+	conf := &tls.Config{
+		ServerName:         c.ServerName(),
+		InsecureSkipVerify: c.SkipVerify(), // nolint:gosec // explicitly requested by the user configuration.
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if c.CAFile() != "" {
+		pem, err := os.ReadFile(c.CAFile())
+		if err != nil {
+			return nil, fmt.Errorf("tls: read ca file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no certificates found in %q", c.CAFile())
+		}
+		conf.RootCAs = pool
+	}
+
+	if (c.CertFile() == "") != (c.KeyFile() == "") {
+		return nil, ErrIncompleteKeyPair
+	}
+	if c.CertFile() != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile(), c.KeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("tls: load key pair: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	return conf, nil
+}
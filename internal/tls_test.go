@@ -0,0 +1,72 @@
+package internal_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/config"
+	"github.com/kenanbek/dbui/internal/tlstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	files, err := tlstest.Generate(t.TempDir(), "localhost")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		conf    config.TLSConfig
+		wantErr bool
+		check   func(*testing.T, *tls.Config)
+	}{
+		{
+			name: "empty",
+			conf: config.TLSConfig{},
+			check: func(t *testing.T, c *tls.Config) {
+				assert.Nil(t, c.RootCAs)
+				assert.Empty(t, c.Certificates)
+				assert.False(t, c.InsecureSkipVerify)
+			},
+		},
+		{
+			name: "full",
+			conf: config.TLSConfig{
+				CAFileProp:     files.CA,
+				CertFileProp:   files.ClientCert,
+				KeyFileProp:    files.ClientKey,
+				ServerNameProp: "db.internal",
+			},
+			check: func(t *testing.T, c *tls.Config) {
+				assert.NotNil(t, c.RootCAs)
+				assert.Len(t, c.Certificates, 1)
+				assert.Equal(t, "db.internal", c.ServerName)
+			},
+		},
+		{
+			name: "skip verify",
+			conf: config.TLSConfig{SkipVerifyProp: true},
+			check: func(t *testing.T, c *tls.Config) {
+				assert.True(t, c.InsecureSkipVerify)
+			},
+		},
+		{name: "missing ca", conf: config.TLSConfig{CAFileProp: "testdata/ghost.pem"}, wantErr: true},
+		{name: "ca without certificates", conf: config.TLSConfig{CAFileProp: files.ClientKey}, wantErr: true},
+		{name: "cert without key", conf: config.TLSConfig{CertFileProp: files.ClientCert}, wantErr: true},
+		{name: "mismatched key pair", conf: config.TLSConfig{CertFileProp: files.ClientCert, KeyFileProp: files.ServerKey}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := tt.conf
+			got, err := internal.NewTLSConfig(&tc)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, got)
+		})
+	}
+}
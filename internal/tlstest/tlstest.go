@@ -0,0 +1,136 @@
+// Package tlstest generates throwaway self-signed certificates for TLS tests.
+package tlstest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Files lists paths of the generated PEM files.
+type Files struct {
+	CA         string
+	ServerCert string
+	ServerKey  string
+	ClientCert string
+	ClientKey  string
+}
+
+type pair struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+// Generate writes a certificate authority together with a server and a client key pair signed by it into dir.
+// The server certificate is valid for the provided host names and IP addresses.
+func Generate(dir string, hosts ...string) (*Files, error) {
+	ca, err := newPair(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: "dbui test CA"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	serverTmpl := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "dbui test server"},
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			serverTmpl.IPAddresses = append(serverTmpl.IPAddresses, ip)
+		} else {
+			serverTmpl.DNSNames = append(serverTmpl.DNSNames, h)
+		}
+	}
+	server, err := newPair(serverTmpl, ca)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newPair(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: "dbui"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+	if err != nil {
+		return nil, err
+	}
+
+	files := &Files{
+		CA:         filepath.Join(dir, "ca.pem"),
+		ServerCert: filepath.Join(dir, "server-cert.pem"),
+		ServerKey:  filepath.Join(dir, "server-key.pem"),
+		ClientCert: filepath.Join(dir, "client-cert.pem"),
+		ClientKey:  filepath.Join(dir, "client-key.pem"),
+	}
+	for _, w := range []struct {
+		path string
+		p    *pair
+		key  bool
+	}{
+		{files.CA, ca, false},
+		{files.ServerCert, server, false},
+		{files.ServerKey, server, true},
+		{files.ClientCert, client, false},
+		{files.ClientKey, client, true},
+	} {
+		if err := writePEM(w.path, w.p, w.key); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+func newPair(tmpl *x509.Certificate, parent *pair) (*pair, error) {
+	// RSA rather than ECDSA: older MySQL builds ship TLS libraries without ECDSA support.
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 62))
+	if err != nil {
+		return nil, err
+	}
+	tmpl.SerialNumber = serial
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(24 * time.Hour)
+
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pair{cert: cert, key: key}, nil
+}
+
+func writePEM(path string, p *pair, key bool) error {
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: p.cert.Raw}
+	if key {
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(p.key)}
+	}
+
+	// World-readable on purpose: the files are copied into database containers running as non-root users.
+	return os.WriteFile(path, pem.EncodeToMemory(block), 0o644) // nolint:gosec
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/kenanbek/dbui/internal/changeset"
+)
+
+// pendingChanges returns the number of pending changes as text, e.g. "1 change" or "3 changes".
+func pendingChanges(n int) string {
+	if n == 1 {
+		return "1 change"
+	}
+	return fmt.Sprintf("%d changes", n)
+}
+
+// reviewChanges shows the pending changes of the previewed table, as a diff of the changed rows followed by
+// the SQL applying them. Confirming runs the statements in a single transaction.
+func (tui *TUI) reviewChanges() {
+	target, err := tui.editTarget()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+	changes := target.tab.changes
+	if changes == nil || changes.Len() == 0 {
+		tui.showWarning("no pending changes")
+		return
+	}
+
+	viewer := NewViewer().SetHang(hangLeading)
+	tui.writeDiff(viewer, changes, target.tab.data)
+	viewer.Write("\n", tui.theme.Text)
+	statements := changes.Statements(target.dialect, target.table, target.tab.data)
+	for _, s := range statements {
+		tui.writeSQL(viewer, target.dialect.Describe(s)+"\n")
+	}
+
+	title := fmt.Sprintf("Apply %s to %s", pendingChanges(changes.Len()), target.tab.table)
+	tui.confirm(title, viewer, func() {
+		if _, err := tui.dc.Current().Execute(target.tab.schema, statements); err != nil {
+			tui.showError(fmt.Errorf("no change was applied: %w", err))
+			return
+		}
+		n := changes.Len()
+		tui.reloadPreview(target.tab)
+		tui.showMessage(fmt.Sprintf("Applied %s to %s", pendingChanges(n), target.tab.table))
+	})
+}
+
+// writeDiff writes the changed rows to the viewer: the old values of updated cells are struck through and followed
+// by the new ones, inserted rows list the values they set, and deleted rows list every value.
+func (tui *TUI) writeDiff(viewer *Viewer, changes *changeset.Changeset, data [][]*string) {
+	value := func(v *string) string {
+		if v == nil {
+			return "NULL"
+		}
+		return *v
+	}
+
+	for _, change := range changes.Diff(data) {
+		keys, keyValues := changes.Key(data, change.Row)
+		key := ""
+		for i, k := range keys {
+			if i > 0 {
+				key += ", "
+			}
+			key += k + " = " + value(keyValues[i])
+		}
+
+		switch change.State {
+		case changeset.Updated:
+			viewer.Write(fmt.Sprintf("~ row %d (%s)\n", change.Row, key), tui.theme.Warning)
+		case changeset.Inserted:
+			viewer.Write("+ new row\n", tui.theme.Message)
+		case changeset.Deleted:
+			viewer.Write(fmt.Sprintf("- row %d (%s)\n", change.Row, key), tui.theme.Error)
+		}
+		for _, cell := range change.Cells {
+			viewer.Write("    "+cell.Column+": ", tui.theme.Header)
+			if change.State != changeset.Inserted {
+				viewer.Write(value(cell.Before), tui.theme.Deleted)
+			}
+			if change.State == changeset.Updated {
+				viewer.Write(" → ", tui.theme.Secondary)
+			}
+			if change.State != changeset.Deleted {
+				viewer.Write(value(cell.After), tui.theme.Changed)
+			}
+			viewer.Write("\n", tui.theme.Text)
+		}
+	}
+}
+
+// discardChanges drops the pending changes of the active tab, restoring the values read from the table.
+func (tui *TUI) discardChanges() {
+	tab := tui.currentTab()
+	if tab.changes == nil || tab.changes.Len() == 0 {
+		tui.showWarning("no pending changes")
+		return
+	}
+
+	n := tab.changes.Len()
+	tui.saveTabState()
+	tab.data = tab.changes.Revert(tab.data)
+	tab.changes, tab.marked = nil, nil
+	tab.row = min(tab.row, len(tab.data)-1)
+	tui.renderTab()
+	tui.showMessage(fmt.Sprintf("Discarded %s", pendingChanges(n)))
+}
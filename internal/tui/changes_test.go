@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/changeset"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTUI_StageChanges(t *testing.T) {
+	ds := &fakeDataSource{columns: []internal.Column{{Name: "id", PrimaryKey: true}}}
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.dc = fakeController{alias: "pg", sources: [][]string{{"pg", "postgresql"}}, ds: ds}
+	*tui.currentTab() = resultTab{label: "users", alias: "pg", schema: "public", table: "users", data: testData(3), row: 1}
+	tui.renderTab()
+
+	target, err := tui.editTarget()
+	require.NoError(t, err)
+	tui.updateCell(target, 1, 0, sptr("10"))
+	tui.PreviewTable.Select(2, 0)
+	tui.deleteRows()
+	tui.insertValues(target, []string{"id"}, []*string{sptr("20")})
+
+	tab := tui.currentTab()
+	assert.Equal(t, 3, tab.changes.Len())
+	assert.Equal(t, changeset.Inserted, tab.changes.State(4))
+	assert.Equal(t, tui.theme.Changed, tui.PreviewTable.GetCell(1, 0).Style)
+	assert.Equal(t, tui.theme.Deleted, tui.PreviewTable.GetCell(2, 0).Style)
+	assert.Equal(t, tui.theme.Text, tui.PreviewTable.GetCell(3, 0).Style)
+	assert.Equal(t, tui.theme.Changed, tui.PreviewTable.GetCell(4, 0).Style)
+	assert.Contains(t, tui.PreviewTable.GetTitle(), "3 changes pending")
+
+	tui.closeTab()
+	assert.Equal(t, tab, tui.currentTab(), "tabs with pending changes stay open")
+
+	tui.discardChanges()
+	assert.Nil(t, tab.changes)
+	assert.Equal(t, testData(3), tab.data)
+	assert.Equal(t, tui.theme.Text, tui.PreviewTable.GetCell(1, 0).Style)
+}
@@ -0,0 +1,260 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/fuzzy"
+	"github.com/kenanbek/dbui/internal/layout"
+	"github.com/rivo/tview"
+)
+
+const (
+	columnsPage    = "columns"
+	jumpColumnPage = "jumpColumn"
+)
+
+// columnWidths lists the maximum column widths cycled through; 0 fits the columns to their content.
+var columnWidths = []int{0, 12, 24, 48}
+
+// defaultLayout is the layout of results without a remembered one: the first column is frozen.
+var defaultLayout = layout.Layout{Frozen: 1}
+
+// layoutOf returns the remembered layout of the previewed table, or the default layout.
+func (tui *TUI) layoutOf(tab *resultTab) layout.Layout {
+	if tab.table == "" || tui.layouts == nil {
+		return defaultLayout
+	}
+	if l, ok := tui.layouts.Get(layout.Key(tab.alias, tab.schema, tab.table)); ok {
+		return l
+	}
+	return defaultLayout
+}
+
+// updateLayout shows the active tab with its changed layout, which is remembered for table previews.
+func (tui *TUI) updateLayout(tab *resultTab) {
+	tui.saveTabState()
+	tui.renderTab()
+	if tab.table == "" || tui.layouts == nil {
+		return
+	}
+	if err := tui.layouts.Set(layout.Key(tab.alias, tab.schema, tab.table), tab.layout); err != nil {
+		tui.showWarning(fmt.Sprintf("Failed to save the column layout: %v", err))
+	}
+}
+
+// selectedColumn returns the name of the selected column of the preview.
+func (tui *TUI) selectedColumn() (string, bool) {
+	tab := tui.currentTab()
+	_, column := tui.PreviewTable.GetSelection()
+	if len(tab.data) == 0 || column < 0 || column >= len(tab.columns) {
+		return "", false
+	}
+
+	return tui.resultColumns()[tab.columns[column]], true
+}
+
+// selectColumn selects the column by its index in the result, keeping the selected row.
+func (tui *TUI) selectColumn(column int) {
+	row, _ := tui.PreviewTable.GetSelection()
+	tui.PreviewTable.Select(row, slices.Index(tui.currentTab().columns, column))
+}
+
+// hideColumn hides the selected column. The last shown column stays.
+func (tui *TUI) hideColumn() {
+	name, ok := tui.selectedColumn()
+	if !ok {
+		tui.showWarning("no column to hide")
+		return
+	}
+	tab := tui.currentTab()
+	if len(tab.columns) == 1 {
+		tui.showWarning("the last shown column cannot be hidden")
+		return
+	}
+
+	tab.layout.Hide(name, true)
+	tui.updateLayout(tab)
+	tui.showMessage(fmt.Sprintf("Column %s hidden, show it again with %s", name, tui.keys.Label(KeyChooseColumnsOp)))
+}
+
+// moveColumn moves the selected column one place to the right (step 1) or to the left (step -1).
+func (tui *TUI) moveColumn(step int) {
+	name, ok := tui.selectedColumn()
+	if !ok {
+		tui.showWarning("no column to move")
+		return
+	}
+
+	tab := tui.currentTab()
+	names := tui.resultColumns()
+	if !tab.layout.Move(names, name, step) {
+		return
+	}
+	tui.updateLayout(tab)
+	tui.selectColumn(slices.Index(names, name))
+}
+
+// freezeColumns keeps the columns up to the selected one in view while scrolling sideways. Freezing the same columns
+// again unfreezes them.
+func (tui *TUI) freezeColumns() {
+	_, column := tui.PreviewTable.GetSelection()
+	tab := tui.currentTab()
+	if column < 0 || column >= len(tab.columns) {
+		tui.showWarning("no column to freeze")
+		return
+	}
+
+	frozen := column + 1
+	if tab.layout.Frozen == frozen {
+		frozen = 0
+	}
+	tab.layout.Frozen = frozen
+	tui.updateLayout(tab)
+	if frozen == 0 {
+		tui.showMessage("No column frozen")
+	} else {
+		tui.showMessage(fmt.Sprintf("%d columns frozen", frozen))
+	}
+}
+
+// cycleColumnWidth switches to the next maximum column width. Longer values are cut with an ellipsis.
+func (tui *TUI) cycleColumnWidth() {
+	tab := tui.currentTab()
+	next := (slices.Index(columnWidths, tab.layout.MaxWidth) + 1) % len(columnWidths)
+	tab.layout.MaxWidth = columnWidths[next]
+	tui.updateLayout(tab)
+	if tab.layout.MaxWidth == 0 {
+		tui.showMessage("Columns fit their content")
+	} else {
+		tui.showMessage(fmt.Sprintf("Columns are at most %d characters wide", tab.layout.MaxWidth))
+	}
+}
+
+// chooseColumns lists every column of the result in the order they are shown, to hide or show them.
+func (tui *TUI) chooseColumns() {
+	tab := tui.currentTab()
+	names := tui.resultColumns()
+	if len(names) == 0 {
+		tui.showWarning("no columns to choose")
+		return
+	}
+
+	list := tview.NewList().ShowSecondaryText(false).
+		SetMainTextStyle(tui.theme.Text).SetSelectedStyle(tui.theme.Selected)
+	list.SetBorder(true).SetTitle("Columns [ Space / Enter: hide or show · Esc: close ]").
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	order := append(slices.Clone(tab.columns), hiddenColumns(tab, names)...)
+	fill := func() {
+		current := list.GetCurrentItem()
+		list.Clear()
+		for _, column := range order {
+			check := "[x]"
+			if slices.Contains(tab.layout.Hidden, names[column]) {
+				check = "[ ]"
+			}
+			list.AddItem(tview.Escape(fmt.Sprintf("%s %s", check, names[column])), "", 0, nil)
+		}
+		list.SetCurrentItem(current)
+	}
+	toggle := func() {
+		name := names[order[list.GetCurrentItem()]]
+		hidden := !slices.Contains(tab.layout.Hidden, name)
+		if hidden && len(tab.columns) == 1 {
+			tui.showWarning("the last shown column cannot be hidden")
+			return
+		}
+		tab.layout.Hide(name, hidden)
+		tui.updateLayout(tab)
+		fill()
+	}
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEnter, event.Key() == tcell.KeyRune && event.Rune() == ' ':
+			toggle()
+		case event.Key() == tcell.KeyEsc:
+			tui.hideOverlay(columnsPage)
+		default:
+			return event
+		}
+		return nil
+	})
+
+	fill()
+	tui.showOverlay(columnsPage, list, 60, min(len(names)+2, 20))
+}
+
+// hiddenColumns returns the indexes of the hidden columns of the result.
+func hiddenColumns(tab *resultTab, names []string) []int {
+	var hidden []int
+	for i := range names {
+		if !slices.Contains(tab.columns, i) {
+			hidden = append(hidden, i)
+		}
+	}
+	return hidden
+}
+
+// jumpColumn searches the columns of the result by name and selects the chosen one, showing it if it was hidden.
+func (tui *TUI) jumpColumn() {
+	tab := tui.currentTab()
+	names := tui.resultColumns()
+	if len(names) == 0 {
+		tui.showWarning("no columns to go to")
+		return
+	}
+
+	var shown []int
+	input := tview.NewInputField().SetLabel("> ").SetFieldStyle(tui.theme.Input).SetLabelStyle(tcell.StyleDefault.Foreground(tui.theme.Title))
+	list := tview.NewList().ShowSecondaryText(false).
+		SetMainTextStyle(tui.theme.Text).SetSelectedStyle(tui.theme.Selected)
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	flex.SetBorder(true).SetTitle("Go to column").SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	filter := func() {
+		shown = fuzzy.Filter(input.GetText(), len(names), func(i int) string { return names[i] })
+		list.Clear()
+		for _, i := range shown {
+			label := names[i]
+			if !slices.Contains(tab.columns, i) {
+				label += " (hidden)"
+			}
+			list.AddItem(tview.Escape(label), "", 0, nil)
+		}
+	}
+	choose := func(index int) {
+		tui.hideOverlay(jumpColumnPage)
+		if index >= len(shown) {
+			return
+		}
+		column := shown[index]
+		if !slices.Contains(tab.columns, column) {
+			tab.layout.Hide(names[column], false)
+			tui.updateLayout(tab)
+		}
+		tui.selectColumn(column)
+	}
+
+	input.SetChangedFunc(func(string) { filter() })
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			list.InputHandler()(event, func(tview.Primitive) {})
+		case tcell.KeyEnter:
+			choose(list.GetCurrentItem())
+		case tcell.KeyEsc:
+			tui.hideOverlay(jumpColumnPage)
+		default:
+			return event
+		}
+		return nil
+	})
+
+	filter()
+	tui.showOverlay(jumpColumnPage, flex, 60, 15)
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kenanbek/dbui/internal/layout"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTUI_Columns(t *testing.T) {
+	store, err := layout.Open(filepath.Join(t.TempDir(), "layouts.json"))
+	require.NoError(t, err)
+	data := [][]*string{{sptr("id"), sptr("name"), sptr("email")}, {sptr("1"), sptr("ann"), sptr("ann@example.com")}}
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.layouts = store
+	tab := tui.currentTab()
+	*tab = resultTab{label: "users", alias: "my", schema: "app", table: "users", data: data, row: 1}
+	tab.layout = tui.layoutOf(tab)
+	tui.renderTab()
+	assert.Equal(t, 1, tab.layout.Frozen, "the first column is frozen by default")
+
+	tui.PreviewTable.Select(1, 1)
+	tui.hideColumn()
+	assert.Equal(t, []int{0, 2}, tab.columns)
+	assert.Equal(t, "email", tui.PreviewTable.GetCell(0, 1).Text)
+
+	tui.PreviewTable.Select(1, 1)
+	tui.moveColumn(-1)
+	assert.Equal(t, []int{2, 0}, tab.columns)
+	_, column := tui.PreviewTable.GetSelection()
+	assert.Equal(t, 0, column, "the moved column stays selected")
+	row, column, ok := tui.selectedCell()
+	assert.True(t, ok)
+	assert.Equal(t, "ann@example.com", *tab.data[row][column])
+
+	tui.PreviewTable.Select(1, 1)
+	tui.freezeColumns()
+	assert.Equal(t, 2, tab.layout.Frozen)
+	tui.freezeColumns()
+	assert.Equal(t, 0, tab.layout.Frozen, "freezing the same columns again unfreezes them")
+
+	tui.cycleColumnWidth()
+	assert.Equal(t, 12, tab.layout.MaxWidth)
+
+	saved, ok := store.Get(layout.Key("my", "app", "users"))
+	assert.True(t, ok)
+	assert.Equal(t, layout.Layout{Order: []string{"email", "name", "id"}, Hidden: []string{"name"}, MaxWidth: 12}, saved)
+
+	tui.PreviewTable.Select(1, 0)
+	tui.hideColumn()
+	tui.PreviewTable.Select(1, 0)
+	tui.hideColumn()
+	assert.Equal(t, []int{0}, tab.columns, "the last shown column stays")
+}
+
+func TestTUI_ColumnsOfQueries(t *testing.T) {
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tab := tui.currentTab()
+	*tab = resultTab{label: "query", sql: "SELECT 1 AS a, 2 AS b", data: [][]*string{{sptr("a"), sptr("b")}, {sptr("1"), sptr("2")}}}
+	tab.layout = tui.layoutOf(tab)
+	tui.renderTab()
+
+	tui.PreviewTable.Select(1, 0)
+	tui.moveColumn(1)
+	assert.Equal(t, []int{1, 0}, tab.columns, "query results are laid out too, without remembering it")
+}
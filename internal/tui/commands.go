@@ -0,0 +1,420 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/export"
+	"github.com/kenanbek/dbui/internal/fuzzy"
+	"github.com/rivo/tview"
+)
+
+const commandPage = "command"
+
+// commandCompletions is the number of argument values the command line lists at most.
+const commandCompletions = 50
+
+type (
+	// command is a command of the command line.
+	command struct {
+		name string
+		// args names the arguments in the completion list, e.g. "<alias>", and nargs counts them.
+		args  string
+		nargs int
+		help  string
+		// complete returns the candidates of the argument following the given ones, or nil for free text.
+		complete func(args []string) []string
+		run      func(args []string) error
+	}
+
+	// commandCompletion is a candidate of the command line: the text replacing the input and how it is listed.
+	commandCompletion struct {
+		text, label string
+	}
+
+	// setting is an option changed with :set.
+	setting struct {
+		name   string
+		values func() []string
+		set    func(value string) error
+	}
+)
+
+// commands returns the commands of the command line: the commands taking arguments, followed by a command
+// per key action, named as in the `keys:` configuration section.
+func (tui *TUI) commands() []command {
+	commands := []command{
+		{name: "connect", args: "<alias>", nargs: 1, help: "Switch to a data source", complete: tui.sourceNames,
+			run: func(args []string) error { return tui.connect(args[0]) }},
+		{name: "use", args: "<schema>", nargs: 1, help: "Switch to a schema", complete: tui.schemaNames,
+			run: func(args []string) error { return tui.use(args[0]) }},
+		{name: "describe", args: "<table>", nargs: 1, help: "Describe a table of the schema", complete: tui.tableNames,
+			run: func(args []string) error { return tui.withSchema(args[0], tui.describeTable) }},
+		{name: "open", args: "<table>", nargs: 1, help: "Preview a table of the schema", complete: tui.tableNames,
+			run: func(args []string) error { return tui.withSchema(args[0], tui.previewNamedTable) }},
+		{name: "export", args: "<format> <file>", nargs: 2, help: "Write the rows of the result to a file", complete: formatNames,
+			run: func(args []string) error {
+				format, err := export.ParseFormat(args[0])
+				if err != nil {
+					return err
+				}
+				return tui.exportRows(format, args[1])
+			}},
+		{name: "set", args: "<option> <value>", nargs: 2, help: "Change an option", complete: tui.settingNames,
+			run: func(args []string) error { return tui.set(args[0], args[1]) }},
+	}
+
+	for _, a := range keyActions {
+		if contextualOps[a.op] {
+			continue
+		}
+		op := a.op
+		commands = append(commands, command{name: a.name, help: fmt.Sprintf("%s (%s)", a.help, tui.keys.Label(op)),
+			run: func([]string) error {
+				tui.runAction(op)
+				return nil
+			}})
+	}
+
+	return commands
+}
+
+// findCommand returns the command with the name.
+func (tui *TUI) findCommand(name string) (command, bool) {
+	for _, c := range tui.commands() {
+		if c.name == name {
+			return c, true
+		}
+	}
+
+	return command{}, false
+}
+
+// splitCommand splits the command line text into words separated by spaces. Text in double or single quotes is part
+// of a word, spaces included, so that file names can contain them, e.g. export csv "my rows.csv".
+func splitCommand(text string) ([]string, error) {
+	scanned, quote := scanCommand(text)
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+
+	words := make([]string, len(scanned))
+	for i, w := range scanned {
+		words[i] = w.text
+	}
+	return words, nil
+}
+
+// commandWord is a word of the command line: its text without quotes, and the byte offsets of its first character
+// and of the one following it in the line.
+type commandWord struct {
+	text       string
+	start, end int
+}
+
+// scanCommand splits the command line text into words the way splitCommand does. It also returns the quote left open
+// at the end of the text, if any, which is part of the last word, as a word being typed may still be in quotes.
+func scanCommand(text string) (words []commandWord, quote rune) {
+	var (
+		word strings.Builder
+		// start is the offset of the word, or -1 before it started, as a quoted empty text is a word too.
+		start = -1
+	)
+	for i, r := range text {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			if start < 0 {
+				start = i
+			}
+		case unicode.IsSpace(r):
+			if start >= 0 {
+				words = append(words, commandWord{text: word.String(), start: start, end: i})
+				word.Reset()
+				start = -1
+			}
+		default:
+			word.WriteRune(r)
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		words = append(words, commandWord{text: word.String(), start: start, end: len(text)})
+	}
+
+	return words, quote
+}
+
+// quoteWord returns the word as typed on the command line, in quotes when it contains spaces or quotes.
+func quoteWord(word string) string {
+	switch {
+	case !strings.ContainsAny(word, " \t\"'"):
+		return word
+	case strings.ContainsRune(word, '"'):
+		return "'" + word + "'"
+	default:
+		return `"` + word + `"`
+	}
+}
+
+// runCommand runs the command line text, e.g. "connect local".
+func (tui *TUI) runCommand(text string) error {
+	fields, err := splitCommand(strings.TrimPrefix(strings.TrimSpace(text), ":"))
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	c, ok := tui.findCommand(fields[0])
+	if !ok {
+		return fmt.Errorf("unknown command %q", fields[0])
+	}
+	if len(fields)-1 != c.nargs {
+		return fmt.Errorf("usage: :%s", strings.TrimSpace(c.name+" "+c.args))
+	}
+
+	return c.run(fields[1:])
+}
+
+// completeCommand returns the candidates completing the last word of the command line text: the command names
+// matching it, or the values of the argument it starts.
+func (tui *TUI) completeCommand(text string) []commandCompletion {
+	scanned, _ := scanCommand(text)
+	// The text ending after a space starts a new word.
+	if len(scanned) == 0 || scanned[len(scanned)-1].end < len(text) {
+		scanned = append(scanned, commandWord{start: len(text), end: len(text)})
+	}
+	words := make([]string, len(scanned))
+	for i, w := range scanned {
+		words[i] = w.text
+	}
+	current := words[len(words)-1]
+	prefix := text[:scanned[len(scanned)-1].start]
+
+	var candidates []commandCompletion
+	if len(words) == 1 {
+		commands := tui.commands()
+		for _, i := range fuzzy.Filter(current, len(commands), func(i int) string { return commands[i].name }) {
+			c := commands[i]
+			completed := c.name
+			if c.nargs > 0 {
+				completed += " "
+			}
+			label := strings.TrimSpace(c.name+" "+c.args) + " · " + c.help
+			candidates = append(candidates, commandCompletion{text: prefix + completed, label: label})
+		}
+	} else {
+		c, ok := tui.findCommand(words[0])
+		if !ok || c.complete == nil || len(words)-1 > c.nargs {
+			return nil
+		}
+		values := c.complete(words[1 : len(words)-1])
+		for _, i := range fuzzy.Filter(current, len(values), func(i int) string { return values[i] }) {
+			completed := prefix + quoteWord(values[i])
+			if len(words)-1 < c.nargs {
+				completed += " "
+			}
+			candidates = append(candidates, commandCompletion{text: completed, label: values[i]})
+		}
+		candidates = candidates[:min(len(candidates), commandCompletions)]
+	}
+
+	return candidates
+}
+
+// showCommandLine opens the command line. The completion list shows the candidates of the word being typed;
+// Tab completes it, and Enter runs the selected candidate or else the typed text.
+func (tui *TUI) showCommandLine() {
+	var candidates []commandCompletion
+
+	input := tview.NewInputField().SetLabel(":").SetFieldStyle(tui.theme.Input).
+		SetLabelStyle(tcell.StyleDefault.Foreground(tui.theme.Title)).
+		SetAutocompleteStyles(tui.theme.Background, tui.theme.Text, tui.theme.Selected)
+	input.SetBorder(true).SetTitle("Command [ Tab: complete · Enter: run · Esc: cancel ]").
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	closeCommandLine := func() {
+		tui.refreshOverlay = nil
+		tui.hideOverlay(commandPage)
+	}
+	run := func(text string) {
+		closeCommandLine()
+		if err := tui.runCommand(text); err != nil {
+			tui.showError(err)
+		}
+	}
+
+	input.SetAutocompleteFunc(func(text string) []string {
+		candidates = tui.completeCommand(text)
+		labels := make([]string, len(candidates))
+		for i, c := range candidates {
+			labels[i] = c.label
+		}
+		return labels
+	})
+	input.SetAutocompletedFunc(func(_ string, index int, source int) bool {
+		if index >= len(candidates) {
+			return true
+		}
+		completed := candidates[index].text
+		switch {
+		case source == tview.AutocompletedNavigate:
+			return false
+		case source == tview.AutocompletedEnter && !strings.HasSuffix(completed, " "):
+			run(completed)
+			return true
+		default:
+			// The candidate needs more arguments, which are completed next.
+			input.SetText(completed)
+			return false
+		}
+	})
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			run(input.GetText())
+		case tcell.KeyEsc:
+			closeCommandLine()
+		}
+	})
+
+	tui.refreshOverlay = func() { input.Autocomplete() }
+	tui.showOverlay(commandPage, input, 80, 3)
+}
+
+// sourceNames returns the aliases of the data sources.
+func (tui *TUI) sourceNames([]string) []string {
+	var aliases []string
+	for _, source := range tui.dc.List() {
+		aliases = append(aliases, source[0])
+	}
+	return aliases
+}
+
+// schemaNames returns the schemas listed in the Schemas view.
+func (tui *TUI) schemaNames([]string) []string {
+	schemas := make([]string, tui.Schemas.GetItemCount())
+	for i := range schemas {
+		schemas[i], _ = tui.Schemas.GetItemText(i)
+	}
+	return schemas
+}
+
+// tableNames returns the tables of the selected schema known to the metadata cache, which loads them if needed.
+func (tui *TUI) tableNames([]string) []string {
+	schema, err := tui.getSelectedSchema()
+	if err != nil {
+		return nil
+	}
+	tables, _ := tui.currentMetadata().Tables(schema)
+	return tables
+}
+
+// formatNames returns the names of the export formats for the first argument of :export.
+func formatNames(args []string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+	names := make([]string, len(export.Formats))
+	for i, f := range export.Formats {
+		names[i] = f.String()
+	}
+	return names
+}
+
+// connect switches to the data source with the alias, as selecting it in the Sources view does.
+func (tui *TUI) connect(alias string) error {
+	for i, source := range tui.dc.List() {
+		if source[0] == alias {
+			tui.Sources.SetCurrentItem(i)
+			tui.sourceSelected(i, alias, "", 0)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown data source %q", alias)
+}
+
+// use switches to the schema, as selecting it in the Schemas view does.
+func (tui *TUI) use(schema string) error {
+	for i := 0; i < tui.Schemas.GetItemCount(); i++ {
+		if name, _ := tui.Schemas.GetItemText(i); name == schema {
+			tui.Schemas.SetCurrentItem(i)
+			tui.schemaSelected(i, schema, "", 0)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown schema %q", schema)
+}
+
+// withSchema calls f with the selected schema and the table.
+func (tui *TUI) withSchema(table string, f func(schema, table string)) error {
+	schema, err := tui.getSelectedSchema()
+	if err != nil {
+		return err
+	}
+	f(schema, table)
+	return nil
+}
+
+// settings returns the options changed with :set.
+func (tui *TUI) settings() []setting {
+	return []setting{
+		{name: "limit", set: func(value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return errors.New("limit must be a positive number of rows")
+			}
+			tui.limit = n
+			tui.showMessage(fmt.Sprintf("Previews show %s per page", countRows(n)))
+			return nil
+		}},
+		{name: "format", values: func() []string { return formatNames(nil) }, set: func(value string) error {
+			format, err := export.ParseFormat(value)
+			if err != nil {
+				return err
+			}
+			tui.copyFormat = format
+			tui.showMessage(fmt.Sprintf("Rows are copied as %s", format))
+			return nil
+		}},
+	}
+}
+
+// settingNames returns the option names for the first argument of :set, and the values of the option for the second.
+func (tui *TUI) settingNames(args []string) []string {
+	var names []string
+	for _, s := range tui.settings() {
+		switch {
+		case len(args) == 0:
+			names = append(names, s.name)
+		case s.name == args[0] && s.values != nil:
+			return s.values()
+		}
+	}
+	return names
+}
+
+// set changes the option.
+func (tui *TUI) set(name, value string) error {
+	for _, s := range tui.settings() {
+		if s.name == name {
+			return s.set(value)
+		}
+	}
+
+	return fmt.Errorf("unknown option %q", name)
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kenanbek/dbui/internal/export"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCommandsTUI(t *testing.T) *TUI {
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.dc = fakeController{alias: "pg", sources: [][]string{{"pg", "postgresql"}, {"local", "sqlite"}}}
+	tui.Schemas = tview.NewList().AddItem("public", "", 0, nil).AddItem("audit", "", 0, nil)
+	return tui
+}
+
+func texts(candidates []commandCompletion) []string {
+	var texts []string
+	for _, c := range candidates {
+		texts = append(texts, c.text)
+	}
+	return texts
+}
+
+func TestTUI_CompleteCommand(t *testing.T) {
+	tui := newTestCommandsTUI(t)
+
+	assert.Len(t, tui.completeCommand(""), len(tui.commands()), "every command is listed")
+	candidates := tui.completeCommand("conn")
+	require.NotEmpty(t, candidates)
+	assert.Equal(t, commandCompletion{text: "connect ", label: "connect <alias> · Switch to a data source"}, candidates[0])
+	assert.Contains(t, texts(tui.completeCommand("qui")), "quit")
+
+	assert.Equal(t, []string{"connect pg", "connect local"}, texts(tui.completeCommand("connect ")))
+	assert.Equal(t, []string{"use  audit"}, texts(tui.completeCommand("use  aud")), "the typed text is kept")
+	assert.Equal(t, []string{"export csv "}, texts(tui.completeCommand("export cs")), "more arguments follow")
+	assert.Empty(t, tui.completeCommand("export csv out"), "file names are not completed")
+	assert.Equal(t, []string{"set limit ", "set format "}, texts(tui.completeCommand("set ")))
+	assert.Contains(t, texts(tui.completeCommand("set format j")), "set format json")
+	assert.Empty(t, tui.completeCommand("nope "))
+	assert.Equal(t, []string{"export csv "}, texts(tui.completeCommand(`export "cs`)), "an open quote is completed")
+	assert.Empty(t, tui.completeCommand(`export csv "my rows`), "quoted words count as one")
+}
+
+func TestTUI_CommandNames(t *testing.T) {
+	names := map[string]bool{}
+	for _, c := range newTestCommandsTUI(t).commands() {
+		assert.False(t, names[c.name], "%s is defined twice", c.name)
+		names[c.name] = true
+	}
+}
+
+func TestTUI_RunCommand(t *testing.T) {
+	tui := newTestCommandsTUI(t)
+
+	assert.NoError(t, tui.runCommand(""))
+	assert.EqualError(t, tui.runCommand("nope"), `unknown command "nope"`)
+	assert.EqualError(t, tui.runCommand("connect"), "usage: :connect <alias>")
+	assert.EqualError(t, tui.runCommand("quit now"), "usage: :quit")
+	assert.EqualError(t, tui.runCommand("connect mongo"), `unknown data source "mongo"`)
+	assert.EqualError(t, tui.runCommand("use nope"), `unknown schema "nope"`)
+
+	require.NoError(t, tui.runCommand(":set limit 500"))
+	assert.Equal(t, 500, tui.pageSize())
+	assert.EqualError(t, tui.runCommand("set limit many"), "limit must be a positive number of rows")
+	require.NoError(t, tui.runCommand("set format json"))
+	assert.Equal(t, export.JSON, tui.copyFormat)
+	assert.EqualError(t, tui.runCommand("set colour red"), `unknown option "colour"`)
+
+	file := filepath.Join(t.TempDir(), "out.csv")
+	assert.EqualError(t, tui.runCommand("export csv "+file), "no rows to export")
+	*tui.currentTab() = resultTab{label: "q", data: [][]*string{{sptr("id"), sptr("name")}, {sptr("1"), sptr("a,b")}}}
+	assert.EqualError(t, tui.runCommand("export xls "+file), `unknown format "xls"`)
+	require.NoError(t, tui.runCommand("export csv "+file))
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "id,name\n1,\"a,b\"\n", string(content))
+
+	spaced := filepath.Join(t.TempDir(), "my rows.csv")
+	require.NoError(t, tui.runCommand(`export csv "`+spaced+`"`))
+	assert.FileExists(t, spaced)
+	assert.EqualError(t, tui.runCommand(`export csv "`+spaced), "unterminated \" quote")
+}
+
+func TestSplitCommand(t *testing.T) {
+	words, err := splitCommand(`export  csv "my rows.csv"`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"export", "csv", "my rows.csv"}, words)
+
+	words, err = splitCommand(`set format 'it''s' ""`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"set", "format", "its", ""}, words, "quotes join the text around them")
+}
+
+func TestQuoteWord(t *testing.T) {
+	assert.Equal(t, "audit", quoteWord("audit"))
+	assert.Equal(t, `"my schema"`, quoteWord("my schema"))
+	assert.Equal(t, `'say "hi"'`, quoteWord(`say "hi"`))
+}
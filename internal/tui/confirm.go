@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/sqlparse"
+)
+
+const confirmPage = "confirm"
+
+// writeSQL writes the SQL text to the viewer with syntax highlighting.
+func (tui *TUI) writeSQL(viewer *Viewer, text string) {
+	for _, t := range sqlparse.Tokenize(text) {
+		viewer.Write(t.Text, tui.theme.tokenStyle(t.Kind))
+	}
+}
+
+// confirm shows what is about to run, written to the viewer, and calls run once the user accepts it with Enter or y.
+// Esc, n and q cancel.
+func (tui *TUI) confirm(title string, viewer *Viewer, run func()) {
+	viewer.SetBorder(true).SetTitle(title + " [ Enter: run · Esc: cancel ]").
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	viewer.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEnter || event.Key() == tcell.KeyRune && event.Rune() == 'y':
+			tui.hideOverlay(confirmPage)
+			run()
+		case event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyRune && (event.Rune() == 'n' || event.Rune() == 'q'):
+			tui.hideOverlay(confirmPage)
+		default:
+			return event
+		}
+		return nil
+	})
+
+	tui.showOverlay(confirmPage, viewer, 100, 20)
+}
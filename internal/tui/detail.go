@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/pretty"
+	"github.com/rivo/uniseg"
+)
+
+const detailPage = "detail"
+
+// recordNameWidth caps the width of the column names in the record view.
+const recordNameWidth = 30
+
+// highlightStyle maps the classes of highlighted documents to theme styles.
+func (tui *TUI) highlightStyle(class pretty.Class) tcell.Style {
+	switch class {
+	case pretty.Key:
+		return tui.theme.Header
+	case pretty.String:
+		return tui.theme.String
+	case pretty.Number:
+		return tui.theme.Number
+	case pretty.Literal, pretty.Tag:
+		return tui.theme.Keyword
+	case pretty.Comment:
+		return tui.theme.Comment
+	default:
+		return tui.theme.Text
+	}
+}
+
+// showDetail shows the viewer in a large overlay which closes with Esc or q. Other keys go to handle first,
+// which returns the keys it does not take.
+func (tui *TUI) showDetail(viewer *Viewer, title string, handle func(event *tcell.EventKey) *tcell.EventKey) {
+	viewer.SetBorder(true).SetTitle(title).SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+	viewer.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyRune && event.Rune() == 'q' {
+			tui.hideOverlay(detailPage)
+			return nil
+		}
+		if handle != nil {
+			return handle(event)
+		}
+		return event
+	})
+
+	tui.showOverlay(detailPage, viewer, 120, 40)
+}
+
+// selectedCell returns the row and column of the data of the cell selected in the preview, if it holds data.
+func (tui *TUI) selectedCell() (int, int, bool) {
+	tab := tui.currentTab()
+	row, column := tui.PreviewTable.GetSelection()
+	if row < 1 || row >= len(tab.data) || column < 0 || column >= len(tab.columns) {
+		return row, column, false
+	}
+
+	return row, tab.columns[column], true
+}
+
+// showCellDetail shows the full value of the selected cell. JSON and XML values are indented and highlighted.
+func (tui *TUI) showCellDetail() {
+	row, column, ok := tui.selectedCell()
+	if !ok {
+		tui.showWarning("no cell to show")
+		return
+	}
+	value := tui.currentTab().data[row][column]
+	name := tui.resultColumns()[column]
+
+	viewer := NewViewer().SetHang(hangLeading)
+	kind := "NULL"
+	if value == nil {
+		viewer.Write("NULL", tui.theme.Secondary)
+	} else {
+		text, k := pretty.Format(*value)
+		for _, span := range pretty.Highlight(text, k) {
+			viewer.Write(text[span.Start:span.End], tui.highlightStyle(span.Class))
+		}
+		kind = k.String()
+	}
+
+	title := fmt.Sprintf("%s · row %d · %s [ %s: copy · Esc: close ]", name, row, kind, tui.keys.Label(KeyCopyCellOp))
+	tui.showDetail(viewer, title, func(event *tcell.EventKey) *tcell.EventKey {
+		if op, ok := tui.keys.Match(ScopePreview, event); ok && op == KeyCopyCellOp {
+			tui.copyCell()
+			return nil
+		}
+		return event
+	})
+}
+
+// showRecord shows the selected row as a list of column names and values, one column per line.
+// n and p move to the next and the previous row.
+func (tui *TUI) showRecord() {
+	row, _, ok := tui.selectedCell()
+	if !ok {
+		tui.showWarning("no row to show")
+		return
+	}
+
+	viewer := NewViewer()
+	last := len(tui.currentTab().data) - 1
+	render := func() {
+		tui.writeRecord(viewer.Clear(), row)
+		viewer.SetTitle(fmt.Sprintf("Record %d of %d [ n / p: next / previous · Esc: close ]", row, last))
+	}
+
+	tui.showDetail(viewer, "", func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyRune || event.Rune() != 'n' && event.Rune() != 'p' {
+			return event
+		}
+		if event.Rune() == 'n' {
+			row = min(last, row+1)
+		} else {
+			row = max(1, row-1)
+		}
+		_, column := tui.PreviewTable.GetSelection()
+		tui.PreviewTable.Select(row, column)
+		render()
+		return nil
+	})
+	render()
+}
+
+// writeRecord writes the row of the active tab as aligned name and value pairs.
+func (tui *TUI) writeRecord(viewer *Viewer, row int) {
+	columns := tui.resultColumns()
+	width := 0
+	for _, name := range columns {
+		width = max(width, min(recordNameWidth, uniseg.StringWidth(name)))
+	}
+	viewer.SetHang(width + 3)
+	padding := strings.Repeat(" ", width+3)
+
+	for i, value := range tui.currentTab().data[row] {
+		if i > 0 {
+			viewer.Write("\n", tui.theme.Text)
+		}
+		name := columns[i]
+		if w := uniseg.StringWidth(name); w < width {
+			name += strings.Repeat(" ", width-w)
+		}
+		viewer.Write(name, tui.theme.Header).Write(" │ ", tui.theme.Secondary)
+
+		if value == nil {
+			viewer.Write("NULL", tui.theme.Secondary)
+			continue
+		}
+		// Continuation lines of multi-line values line up with the first one.
+		viewer.Write(strings.ReplaceAll(*value, "\n", "\n"+padding), tui.theme.Text)
+	}
+}
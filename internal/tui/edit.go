@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/changeset"
+	"github.com/kenanbek/dbui/internal/sqlgen"
+	"github.com/rivo/tview"
+)
+
+const editPage = "edit"
+
+// editTarget is the table the rows of the active tab are changed in.
+type editTarget struct {
+	tab     *resultTab
+	dialect sqlgen.Dialect
+	// table is the quoted name of the table.
+	table   string
+	columns []internal.Column
+	// keys are the indexes of the primary key columns in the result.
+	keys []int
+}
+
+// editTarget returns the table of the active tab if its rows can be changed: the tab previews a single table
+// of the current data source, and the table has a primary key whose columns are part of the result.
+func (tui *TUI) editTarget() (editTarget, error) {
+	tab := tui.currentTab()
+	if tab.table == "" || tab.sql != "" {
+		return editTarget{}, errors.New("only table previews can be edited")
+	}
+	if tab.alias != tui.dc.CurrentAlias() {
+		return editTarget{}, fmt.Errorf("the result comes from %s, switch to it to edit", tab.alias)
+	}
+
+	columns, err := tui.dc.Current().ListColumns(tab.schema, tab.table)
+	if err != nil {
+		return editTarget{}, err
+	}
+
+	target := editTarget{tab: tab, dialect: sqlgen.DialectFor(tui.typeOf(tab.alias)), columns: columns}
+	target.table = target.dialect.QuoteIdent(tab.table)
+	names := tui.resultColumns()
+	for _, c := range columns {
+		if !c.PrimaryKey {
+			continue
+		}
+		index := -1
+		for i, name := range names {
+			if name == c.Name {
+				index = i
+			}
+		}
+		if index < 0 {
+			return editTarget{}, fmt.Errorf("the primary key column %s is not part of the result", c.Name)
+		}
+		target.keys = append(target.keys, index)
+	}
+	if len(target.keys) == 0 {
+		return editTarget{}, fmt.Errorf("%s has no primary key, so its rows cannot be edited", tab.table)
+	}
+
+	return target, nil
+}
+
+// changes returns the pending changes of the target, starting a changeset for the first one.
+func (t editTarget) changes() *changeset.Changeset {
+	if t.tab.changes == nil {
+		t.tab.changes = changeset.New(t.keys)
+	}
+	return t.tab.changes
+}
+
+// editCell opens an editor for the value of the selected cell. The new value is staged in the changeset of the tab.
+func (tui *TUI) editCell() {
+	row, column, ok := tui.selectedCell()
+	if !ok {
+		tui.showWarning("no cell to edit")
+		return
+	}
+	target, err := tui.editTarget()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+	if target.tab.changes != nil && target.tab.changes.State(row) == changeset.Deleted {
+		tui.showWarning(fmt.Sprintf("the row is to be deleted, keep it with %s to edit it", tui.keys.Label(KeyDeleteRowsOp)))
+		return
+	}
+	name := tui.resultColumns()[column]
+	value := target.tab.data[row][column]
+
+	text := ""
+	if value != nil {
+		text = *value
+	}
+	null := tview.NewCheckbox().SetLabel("NULL").SetChecked(value == nil)
+	area := tview.NewTextArea().SetText(text, true).SetLabel(name)
+	area.SetChangedFunc(func() { null.SetChecked(false) })
+
+	form := tview.NewForm().SetFieldStyle(tui.theme.Input).SetLabelColor(tui.theme.Title).
+		SetButtonStyle(tui.theme.Input).SetButtonActivatedStyle(tui.theme.Selected).
+		AddFormItem(area).AddFormItem(null)
+	update := func() {
+		tui.hideOverlay(editPage)
+		var newValue *string
+		if !null.IsChecked() {
+			newValue = new(string)
+			*newValue = area.GetText()
+		}
+		tui.updateCell(target, row, column, newValue)
+	}
+	form.AddButton("Update", update)
+	form.AddButton("Cancel", func() { tui.hideOverlay(editPage) })
+	form.SetCancelFunc(func() { tui.hideOverlay(editPage) })
+	form.SetBorder(true).SetTitle(fmt.Sprintf("%s · row %d [ Ctrl-S: update · Esc: cancel ]", target.tab.table, row)).
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyCtrlS {
+			update()
+			return nil
+		}
+		return event
+	})
+
+	tui.showOverlay(editPage, form, 80, 13)
+}
+
+// updateCell stages the new value of the cell, which is highlighted until the changes are applied or discarded.
+// Nothing is staged when the result was replaced or its tab closed while the editor was open.
+func (tui *TUI) updateCell(target editTarget, row, column int, value *string) {
+	if !tui.shown(target.tab) {
+		tui.showWarning("the result changed while editing, the new value was not staged")
+		return
+	}
+	target.changes().Update(target.tab.data, row, column, value)
+	tui.saveTabState()
+	tui.renderTab()
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/eventlog"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDataSource returns fixed columns and rows, recording the previews asked for.
+type fakeDataSource struct {
+	internal.DataSource
+	columns  []internal.Column
+	data     [][]*string
+	previews []internal.Preview
+}
+
+func (d *fakeDataSource) PreviewTable(_, _ string, preview internal.Preview) ([][]*string, error) {
+	d.previews = append(d.previews, preview)
+	return d.data, nil
+}
+
+func (d *fakeDataSource) ListColumns(_, _ string) ([]internal.Column, error) {
+	return d.columns, nil
+}
+
+func TestTUI_EditTarget(t *testing.T) {
+	ds := &fakeDataSource{columns: []internal.Column{{Name: "id", PrimaryKey: true}, {Name: "name"}}}
+	tui := newTestTUI(t)
+	tui.dc = fakeController{alias: "pg", sources: [][]string{{"pg", "postgresql"}}, ds: ds}
+	data := [][]*string{{sptr("name"), sptr("id")}, {sptr("a"), sptr("7")}}
+
+	*tui.currentTab() = resultTab{alias: "pg", schema: "public", table: "users", data: data}
+	target, err := tui.editTarget()
+	require.NoError(t, err)
+	assert.Equal(t, `"users"`, target.table)
+	assert.Equal(t, []int{1}, target.keys)
+	keys, values := target.changes().Key(data, 1)
+	assert.Equal(t, []string{"id"}, keys)
+	assert.Equal(t, []*string{sptr("7")}, values)
+
+	*tui.currentTab() = resultTab{alias: "pg", schema: "public", table: "users", sql: "select * from users", data: data}
+	_, err = tui.editTarget()
+	assert.EqualError(t, err, "only table previews can be edited")
+
+	*tui.currentTab() = resultTab{alias: "my", schema: "public", table: "users", data: data}
+	_, err = tui.editTarget()
+	assert.EqualError(t, err, "the result comes from my, switch to it to edit")
+
+	*tui.currentTab() = resultTab{alias: "pg", schema: "public", table: "users", data: [][]*string{{sptr("name")}}}
+	_, err = tui.editTarget()
+	assert.EqualError(t, err, "the primary key column id is not part of the result")
+
+	ds.columns = []internal.Column{{Name: "name"}}
+	_, err = tui.editTarget()
+	assert.EqualError(t, err, "users has no primary key, so its rows cannot be edited")
+}
+
+func TestTUI_UpdateCellReplaced(t *testing.T) {
+	ds := &fakeDataSource{columns: []internal.Column{{Name: "id", PrimaryKey: true}}}
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.dc = fakeController{alias: "pg", sources: [][]string{{"pg", "postgresql"}}, ds: ds}
+	*tui.currentTab() = resultTab{label: "users", alias: "pg", schema: "public", table: "users", data: testData(3), row: 1}
+	tui.renderTab()
+
+	target, err := tui.editTarget()
+	require.NoError(t, err)
+	// A preview finishing while the editor is open replaces the result with a shorter one.
+	tui.tabs[tui.activeTab] = &resultTab{label: "users", alias: "pg", schema: "public", table: "users", data: testData(1), row: 1}
+	tui.renderTab()
+
+	assert.NotPanics(t, func() { tui.updateCell(target, 3, 0, sptr("10")) })
+	assert.Nil(t, tui.currentTab().changes)
+	assert.Equal(t, testData(1), tui.currentTab().data)
+	entries := tui.messages.Entries()
+	assert.Equal(t, eventlog.Warning, entries[len(entries)-1].Severity)
+}
@@ -0,0 +1,677 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/completion"
+	"github.com/kenanbek/dbui/internal/sqlparse"
+	"github.com/rivo/tview"
+	"github.com/rivo/uniseg"
+)
+
+// editorUndoLimit caps the number of undo steps the editor keeps.
+const editorUndoLimit = 500
+
+// DefaultIndent is the indentation width used when the configuration does not set one.
+const DefaultIndent = 2
+
+// Editor is a multi-line SQL editor with syntax highlighting, line numbers, undo/redo and bracket matching.
+// The cursor and the selection anchor are rune offsets into the text; nothing is selected when they are equal.
+type Editor struct {
+	*tview.Box
+
+	theme    *Theme
+	indent   string
+	tabWidth int
+
+	text   []rune
+	cursor int
+	anchor int
+	// column is the display column kept while moving up and down, or -1.
+	column int
+
+	undo, redo []editorSnapshot
+	// typing is set while consecutive typed characters join the same undo step.
+	typing bool
+
+	rowOffset, columnOffset int
+	height                  int
+	// scrolled is set when the mouse wheel moved the view away from the cursor.
+	scrolled bool
+	dragging bool
+
+	complete   CompleteFunc
+	completing bool
+	// suggestions are the candidates of the open completion popup, replacing the text from suggestFrom up to the cursor.
+	suggestions []completion.Candidate
+	suggestion  int
+	suggestFrom int
+}
+
+type editorSnapshot struct {
+	text           []rune
+	cursor, anchor int
+}
+
+// NewEditor returns an empty editor. Indentation is width spaces, or a tab displayed width columns wide.
+func NewEditor(theme *Theme, width int, tabs bool) *Editor {
+	if width <= 0 {
+		width = DefaultIndent
+	}
+	indent := strings.Repeat(" ", width)
+	if tabs {
+		indent = "\t"
+	}
+
+	return &Editor{Box: tview.NewBox(), theme: theme, indent: indent, tabWidth: width, column: -1}
+}
+
+// GetText returns the whole text of the editor.
+func (e *Editor) GetText() string {
+	return string(e.text)
+}
+
+// SetText replaces the text and moves the cursor to its end. The replacement can be undone.
+func (e *Editor) SetText(text string) *Editor {
+	e.pushUndo(false)
+	e.text = []rune(normalizeNewlines(text))
+	e.cursor, e.anchor, e.column = len(e.text), len(e.text), -1
+	e.closeCompletion()
+
+	return e
+}
+
+// Selection returns the selected text, if any.
+func (e *Editor) Selection() (string, bool) {
+	if e.cursor == e.anchor {
+		return "", false
+	}
+	from, to := e.selection()
+
+	return string(e.text[from:to]), true
+}
+
+// Statement returns the selected text, or the statement under the cursor when nothing is selected.
+func (e *Editor) Statement() string {
+	if s, ok := e.Selection(); ok {
+		return strings.TrimSpace(s)
+	}
+
+	s, _ := sqlparse.StatementAt(string(e.text), len(string(e.text[:e.cursor])))
+	return s.Text
+}
+
+// Insert replaces the selection with the text, or inserts it at the cursor.
+func (e *Editor) Insert(text string) {
+	e.pushUndo(false)
+	e.replace([]rune(normalizeNewlines(text)))
+}
+
+// Newline breaks the line at the cursor and indents the new line like the current one,
+// one level deeper after an opening bracket.
+func (e *Editor) Newline() {
+	e.pushUndo(false)
+	from, _ := e.selection()
+	start := e.lineStart(from)
+	indent := leadingSpace(e.text[start:from])
+	if from > start && e.text[from-1] == '(' {
+		indent += e.indent
+	}
+	e.replace([]rune("\n" + indent))
+}
+
+// Backspace deletes the selection or the character before the cursor. In leading
+// whitespace indented with spaces, it deletes back to the previous indentation stop.
+func (e *Editor) Backspace() {
+	from := e.anchor
+	if e.cursor == e.anchor {
+		if e.cursor == 0 {
+			return
+		}
+		n := 1
+		start := e.lineStart(e.cursor)
+		if prefix := e.text[start:e.cursor]; len(prefix) > 0 && e.indent != "\t" && strings.Trim(string(prefix), " ") == "" {
+			if n = len(prefix) % e.tabWidth; n == 0 {
+				n = e.tabWidth
+			}
+		}
+		from = e.cursor - n
+	}
+	e.pushUndo(false)
+	e.anchor = from
+	e.replace(nil)
+}
+
+// Delete deletes the selection or the character under the cursor.
+func (e *Editor) Delete() {
+	to := e.anchor
+	if e.cursor == e.anchor {
+		if e.cursor == len(e.text) {
+			return
+		}
+		to = e.cursor + 1
+	}
+	e.pushUndo(false)
+	e.anchor = to
+	e.replace(nil)
+}
+
+// Indent indents the selected lines, or inserts indentation at the cursor when nothing is selected.
+func (e *Editor) Indent() {
+	if e.cursor != e.anchor {
+		e.shiftLines(false)
+		return
+	}
+
+	indent := e.indent
+	if indent != "\t" {
+		_, col := e.position(e.cursor)
+		indent = strings.Repeat(" ", e.tabWidth-col%e.tabWidth)
+	}
+	e.Insert(indent)
+}
+
+// Outdent removes one indentation level from the selected lines or from the line under the cursor.
+func (e *Editor) Outdent() {
+	e.shiftLines(true)
+}
+
+// Undo reverts the last change.
+func (e *Editor) Undo() {
+	if len(e.undo) == 0 {
+		return
+	}
+	e.redo = append(e.redo, e.snapshot())
+	e.restore(e.undo[len(e.undo)-1])
+	e.undo = e.undo[:len(e.undo)-1]
+}
+
+// Redo reapplies the last undone change.
+func (e *Editor) Redo() {
+	if len(e.redo) == 0 {
+		return
+	}
+	e.undo = append(e.undo, e.snapshot())
+	e.restore(e.redo[len(e.redo)-1])
+	e.redo = e.redo[:len(e.redo)-1]
+}
+
+func (e *Editor) snapshot() editorSnapshot {
+	return editorSnapshot{text: append([]rune(nil), e.text...), cursor: e.cursor, anchor: e.anchor}
+}
+
+func (e *Editor) restore(s editorSnapshot) {
+	e.text, e.cursor, e.anchor, e.column, e.typing = s.text, s.cursor, s.anchor, -1, false
+}
+
+// pushUndo records the current state before a change. Typed characters are grouped into a single step.
+func (e *Editor) pushUndo(typing bool) {
+	if !typing || !e.typing {
+		e.undo = append(e.undo, e.snapshot())
+		if len(e.undo) > editorUndoLimit {
+			e.undo = e.undo[1:]
+		}
+	}
+	e.redo = nil
+	e.typing = typing
+}
+
+// replace replaces the selection with the runes and places the cursor after them.
+func (e *Editor) replace(runes []rune) {
+	from, to := e.selection()
+	text := make([]rune, 0, len(e.text)-(to-from)+len(runes))
+	text = append(append(append(text, e.text[:from]...), runes...), e.text[to:]...)
+	e.text = text
+	e.cursor, e.anchor, e.column = from+len(runes), from+len(runes), -1
+}
+
+// shiftLines indents or outdents every line touched by the selection.
+func (e *Editor) shiftLines(outdent bool) {
+	from, to := e.selection()
+	if to > from && e.text[to-1] == '\n' {
+		to--
+	}
+	first, last := e.row(from), e.row(to)
+	starts := e.lineStarts()
+
+	e.pushUndo(false)
+	shift := func(pos, at, delta int) int {
+		switch {
+		case pos < at:
+			return pos
+		case pos < at-delta:
+			return at
+		default:
+			return pos + delta
+		}
+	}
+	// Lines are edited back to front so the offsets of the remaining ones stay valid.
+	for row := last; row >= first; row-- {
+		start := starts[row]
+		var edit []rune
+		delta := 0
+		if outdent {
+			switch {
+			case start < len(e.text) && e.text[start] == '\t':
+				delta = -1
+			default:
+				for -delta < e.tabWidth && start-delta < len(e.text) && e.text[start-delta] == ' ' {
+					delta--
+				}
+			}
+			edit = e.text[start-delta:]
+		} else {
+			if start == len(e.text) || e.text[start] == '\n' {
+				continue
+			}
+			edit = append([]rune(e.indent), e.text[start:]...)
+			delta = len(e.indent)
+		}
+		e.text = append(e.text[:start:start], edit...)
+		e.cursor, e.anchor = shift(e.cursor, start, delta), shift(e.anchor, start, delta)
+	}
+	e.column = -1
+}
+
+func (e *Editor) typeRune(r rune) {
+	e.pushUndo(!unicode.IsSpace(r) && e.cursor == e.anchor)
+	e.replace([]rune{r})
+}
+
+// moveTo moves the cursor, extending the selection or dropping it.
+func (e *Editor) moveTo(pos int, extend bool) {
+	e.cursor = max(0, min(pos, len(e.text)))
+	if !extend {
+		e.anchor = e.cursor
+	}
+	e.column, e.typing = -1, false
+}
+
+// moveVertically moves the cursor by the given number of lines, keeping its display column.
+func (e *Editor) moveVertically(lines int, extend bool) {
+	row, col := e.position(e.cursor)
+	if e.column < 0 {
+		e.column = col
+	}
+	column := e.column
+
+	switch target := row + lines; {
+	case target < 0:
+		e.moveTo(0, extend)
+	case target >= len(e.lineStarts()):
+		e.moveTo(len(e.text), extend)
+	default:
+		e.moveTo(e.offsetAt(target, column), extend)
+		e.column = column
+	}
+}
+
+func (e *Editor) selection() (from, to int) {
+	return min(e.cursor, e.anchor), max(e.cursor, e.anchor)
+}
+
+func (e *Editor) lineStart(pos int) int {
+	for pos > 0 && e.text[pos-1] != '\n' {
+		pos--
+	}
+	return pos
+}
+
+func (e *Editor) lineEnd(pos int) int {
+	for pos < len(e.text) && e.text[pos] != '\n' {
+		pos++
+	}
+	return pos
+}
+
+// lineStarts returns the offsets of the first character of every line.
+func (e *Editor) lineStarts() []int {
+	starts := []int{0}
+	for i, r := range e.text {
+		if r == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+func (e *Editor) row(pos int) int {
+	starts := e.lineStarts()
+	return sort.Search(len(starts), func(i int) bool { return starts[i] > pos }) - 1
+}
+
+// onFirstLine reports whether the cursor is on the first line of the text.
+func (e *Editor) onFirstLine() bool {
+	return e.lineStart(e.cursor) == 0
+}
+
+// onLastLine reports whether the cursor is on the last line of the text.
+func (e *Editor) onLastLine() bool {
+	return e.lineEnd(e.cursor) == len(e.text)
+}
+
+// position returns the line and the display column of an offset.
+func (e *Editor) position(pos int) (row, col int) {
+	start := e.lineStart(pos)
+	for _, r := range e.text[start:pos] {
+		col += e.runeWidth(r, col)
+	}
+	return e.row(pos), col
+}
+
+// offsetAt returns the offset of the character displayed at the given line and column.
+func (e *Editor) offsetAt(row, col int) int {
+	starts := e.lineStarts()
+	row = max(0, min(row, len(starts)-1))
+	pos, x := starts[row], 0
+	for pos < len(e.text) && e.text[pos] != '\n' {
+		w := e.runeWidth(e.text[pos], x)
+		if x+w > col {
+			break
+		}
+		x += w
+		pos++
+	}
+	return pos
+}
+
+func (e *Editor) runeWidth(r rune, col int) int {
+	if r == '\t' {
+		return e.tabWidth - col%e.tabWidth
+	}
+	return max(1, uniseg.StringWidth(string(r)))
+}
+
+func (e *Editor) wordLeft() int {
+	pos := e.cursor
+	for pos > 0 && !isWordRune(e.text[pos-1]) {
+		pos--
+	}
+	for pos > 0 && isWordRune(e.text[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+func (e *Editor) wordRight() int {
+	pos := e.cursor
+	for pos < len(e.text) && !isWordRune(e.text[pos]) {
+		pos++
+	}
+	for pos < len(e.text) && isWordRune(e.text[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// home returns the first non-blank character of the line, or the line start when the cursor is already there.
+func (e *Editor) home() int {
+	start := e.lineStart(e.cursor)
+	first := start + len([]rune(leadingSpace(e.text[start:e.lineEnd(e.cursor)])))
+	if e.cursor == first {
+		return start
+	}
+	return first
+}
+
+// styles returns the style of every character: syntax highlighting, selection and matching brackets.
+func (e *Editor) styles() []tcell.Style {
+	styles := make([]tcell.Style, len(e.text))
+	tokens := sqlparse.Tokenize(string(e.text))
+	runeOffsets := make(map[int]int, len(tokens))
+
+	pos := 0
+	for _, t := range tokens {
+		runeOffsets[t.Start] = pos
+		style := e.theme.tokenStyle(t.Kind)
+		for n := utf8.RuneCountInString(t.Text); n > 0; n-- {
+			styles[pos] = style
+			pos++
+		}
+	}
+
+	// The bracket under the cursor wins over the one before it.
+	for _, at := range []int{e.cursor, e.cursor - 1} {
+		if at < 0 || at >= len(e.text) || !strings.ContainsRune("()[]{}", e.text[at]) {
+			continue
+		}
+		byteOffset := len(string(e.text[:at]))
+		if _, ok := runeOffsets[byteOffset]; !ok {
+			continue
+		}
+		if partner, ok := sqlparse.MatchBracket(tokens, byteOffset); ok {
+			styles[at] = e.theme.MatchingBracket
+			styles[runeOffsets[partner]] = e.theme.MatchingBracket
+			break
+		}
+	}
+
+	from, to := e.selection()
+	for i := from; i < to; i++ {
+		styles[i] = e.theme.Selected
+	}
+
+	return styles
+}
+
+// gutterWidth returns the width of the line numbers column, including its padding.
+func (e *Editor) gutterWidth(width int) int {
+	gutter := len(strconv.Itoa(len(e.lineStarts()))) + 1
+	if gutter >= width {
+		return 0
+	}
+	return gutter
+}
+
+// Draw draws this primitive onto the screen.
+func (e *Editor) Draw(screen tcell.Screen) {
+	e.DrawForSubclass(screen, e)
+	x, y, width, height := e.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	e.height = height
+
+	starts := e.lineStarts()
+	gutter := e.gutterWidth(width)
+	textWidth := width - gutter
+	row, col := e.position(e.cursor)
+	if !e.scrolled {
+		e.rowOffset = max(min(e.rowOffset, row), row-height+1)
+		e.columnOffset = max(min(e.columnOffset, col), col-textWidth+1)
+	}
+
+	styles := e.styles()
+	for r := e.rowOffset; r < len(starts) && r < e.rowOffset+height; r++ {
+		sy := y + r - e.rowOffset
+		if gutter > 0 {
+			numberStyle := e.theme.LineNumber
+			if r == row {
+				numberStyle = e.theme.Text
+			}
+			number := strconv.Itoa(r + 1)
+			for i, c := range number {
+				screen.SetContent(x+gutter-1-len(number)+i, sy, c, nil, numberStyle)
+			}
+		}
+
+		column := 0
+		for pos := starts[r]; pos < len(e.text) && e.text[pos] != '\n'; pos++ {
+			c := e.text[pos]
+			w := e.runeWidth(c, column)
+			if column >= e.columnOffset && column+w <= e.columnOffset+textWidth {
+				sx := x + gutter + column - e.columnOffset
+				if c == '\t' {
+					for i := 0; i < w; i++ {
+						screen.SetContent(sx+i, sy, ' ', nil, styles[pos])
+					}
+				} else {
+					screen.SetContent(sx, sy, c, nil, styles[pos])
+				}
+			}
+			column += w
+		}
+	}
+
+	if e.HasFocus() {
+		cx, cy := x+gutter+col-e.columnOffset, y+row-e.rowOffset
+		if cx >= x+gutter && cx < x+width && cy >= y && cy < y+height {
+			screen.ShowCursor(cx, cy)
+		}
+
+		fromRow, fromCol := e.position(e.suggestFrom)
+		e.drawCompletion(screen, x+gutter+fromCol-e.columnOffset, y+fromRow-e.rowOffset)
+	}
+}
+
+// InputHandler returns the handler for this primitive.
+func (e *Editor) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return e.WrapInputHandler(func(event *tcell.EventKey, _ func(p tview.Primitive)) {
+		e.scrolled = false
+		if e.completionKey(event) {
+			e.handleCompletionKey(event)
+			return
+		}
+		extend := event.Modifiers()&tcell.ModShift != 0
+		jump := event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt) != 0
+		from, to := e.selection()
+
+		// Typing a name or a qualifier opens the completion popup, deleting refreshes it and anything else closes it.
+		switch {
+		case event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModAlt == 0 && (isWordRune(event.Rune()) || event.Rune() == '.'):
+			defer e.autoComplete()
+		case (event.Key() == tcell.KeyBackspace || event.Key() == tcell.KeyBackspace2) && e.completing:
+			defer e.RefreshCompletion()
+		default:
+			e.closeCompletion()
+		}
+
+		switch event.Key() {
+		case tcell.KeyRune:
+			if event.Modifiers()&tcell.ModAlt == 0 {
+				e.typeRune(event.Rune())
+			}
+		case tcell.KeyEnter:
+			e.Newline()
+		case tcell.KeyTab:
+			e.Indent()
+		case tcell.KeyBacktab:
+			e.Outdent()
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			e.Backspace()
+		case tcell.KeyDelete:
+			e.Delete()
+		case tcell.KeyLeft:
+			switch {
+			case jump:
+				e.moveTo(e.wordLeft(), extend)
+			case from != to && !extend:
+				e.moveTo(from, false)
+			default:
+				e.moveTo(e.cursor-1, extend)
+			}
+		case tcell.KeyRight:
+			switch {
+			case jump:
+				e.moveTo(e.wordRight(), extend)
+			case from != to && !extend:
+				e.moveTo(to, false)
+			default:
+				e.moveTo(e.cursor+1, extend)
+			}
+		case tcell.KeyUp:
+			e.moveVertically(-1, extend)
+		case tcell.KeyDown:
+			e.moveVertically(1, extend)
+		case tcell.KeyPgUp:
+			e.moveVertically(-max(1, e.height-1), extend)
+		case tcell.KeyPgDn:
+			e.moveVertically(max(1, e.height-1), extend)
+		case tcell.KeyHome:
+			if jump {
+				e.moveTo(0, extend)
+			} else {
+				e.moveTo(e.home(), extend)
+			}
+		case tcell.KeyEnd:
+			if jump {
+				e.moveTo(len(e.text), extend)
+			} else {
+				e.moveTo(e.lineEnd(e.cursor), extend)
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (e *Editor) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return e.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		mx, my := event.Position()
+		if !e.InRect(mx, my) && !e.dragging {
+			return false, nil
+		}
+
+		x, y, width, _ := e.GetInnerRect()
+		at := func() int {
+			return e.offsetAt(e.rowOffset+my-y, max(0, e.columnOffset+mx-x-e.gutterWidth(width)))
+		}
+
+		switch action {
+		case tview.MouseLeftDown:
+			setFocus(e)
+			e.scrolled = false
+			e.closeCompletion()
+			e.moveTo(at(), event.Modifiers()&tcell.ModShift != 0)
+			e.dragging = true
+			return true, e
+		case tview.MouseMove:
+			if e.dragging {
+				e.moveTo(at(), true)
+				return true, e
+			}
+		case tview.MouseLeftUp:
+			if e.dragging {
+				e.dragging = false
+				return true, nil
+			}
+		case tview.MouseScrollUp:
+			e.rowOffset, e.scrolled = max(0, e.rowOffset-1), true
+			return true, nil
+		case tview.MouseScrollDown:
+			e.rowOffset, e.scrolled = max(0, min(e.rowOffset+1, len(e.lineStarts())-1)), true
+			return true, nil
+		}
+
+		return e.InRect(mx, my), nil
+	})
+}
+
+// PasteHandler returns the handler inserting pasted text as a single undo step.
+func (e *Editor) PasteHandler() func(text string, setFocus func(p tview.Primitive)) {
+	return e.WrapPasteHandler(func(text string, _ func(p tview.Primitive)) {
+		e.scrolled = false
+		e.closeCompletion()
+		e.Insert(text)
+	})
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func leadingSpace(line []rune) string {
+	n := 0
+	for n < len(line) && (line[n] == ' ' || line[n] == '\t') {
+		n++
+	}
+	return string(line[:n])
+}
+
+func normalizeNewlines(text string) string {
+	return strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text)
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/completion"
+	"github.com/rivo/uniseg"
+)
+
+// completionRows is the number of suggestions the completion popup shows at once.
+const completionRows = 8
+
+// CompleteFunc returns the completion candidates for the word ending at the byte offset of the text,
+// and the byte offset where that word starts.
+type CompleteFunc func(text string, offset int) (int, []completion.Candidate)
+
+// SetCompleteFunc sets the function providing completion candidates. Without it, the editor completes nothing.
+func (e *Editor) SetCompleteFunc(f CompleteFunc) *Editor {
+	e.complete = f
+	return e
+}
+
+// Complete opens the completion popup for the word before the cursor.
+func (e *Editor) Complete() {
+	e.completing = true
+	e.RefreshCompletion()
+}
+
+// RefreshCompletion recomputes the suggestions of an open completion popup, e.g. when more metadata became available.
+func (e *Editor) RefreshCompletion() {
+	if !e.completing || e.complete == nil || e.cursor != e.anchor {
+		e.closeCompletion()
+		return
+	}
+
+	selected := ""
+	if e.suggestion < len(e.suggestions) {
+		selected = e.suggestions[e.suggestion].Text
+	}
+
+	text := string(e.text)
+	from, candidates := e.complete(text, len(string(e.text[:e.cursor])))
+	e.suggestFrom = len([]rune(text[:from]))
+	e.suggestions, e.suggestion = candidates, 0
+	for i, c := range candidates {
+		if c.Text == selected {
+			e.suggestion = i
+		}
+	}
+}
+
+// autoComplete opens the completion popup while a word or a qualified name is being typed,
+// but not for an empty word, where every keyword would match.
+func (e *Editor) autoComplete() {
+	e.Complete()
+	if e.suggestFrom == e.cursor && (e.cursor == 0 || e.text[e.cursor-1] != '.') {
+		e.closeCompletion()
+	}
+}
+
+func (e *Editor) closeCompletion() {
+	e.completing, e.suggestions, e.suggestion = false, nil, 0
+}
+
+// completionKey reports whether the key is handled by the open completion popup.
+func (e *Editor) completionKey(event *tcell.EventKey) bool {
+	if len(e.suggestions) == 0 {
+		return false
+	}
+
+	switch event.Key() {
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn, tcell.KeyEnter, tcell.KeyTab, tcell.KeyEsc:
+		return event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt) == 0
+	default:
+		return false
+	}
+}
+
+// handleCompletionKey moves through the suggestions, accepts or dismisses them.
+func (e *Editor) handleCompletionKey(event *tcell.EventKey) {
+	last := len(e.suggestions) - 1
+	switch event.Key() {
+	case tcell.KeyUp:
+		if e.suggestion--; e.suggestion < 0 {
+			e.suggestion = last
+		}
+	case tcell.KeyDown:
+		if e.suggestion++; e.suggestion > last {
+			e.suggestion = 0
+		}
+	case tcell.KeyPgUp:
+		e.suggestion = max(0, e.suggestion-completionRows)
+	case tcell.KeyPgDn:
+		e.suggestion = min(last, e.suggestion+completionRows)
+	case tcell.KeyEnter, tcell.KeyTab:
+		e.acceptCompletion()
+	case tcell.KeyEsc:
+		e.closeCompletion()
+	}
+}
+
+func (e *Editor) acceptCompletion() {
+	candidate := e.suggestions[e.suggestion]
+	e.closeCompletion()
+
+	e.pushUndo(false)
+	e.anchor = e.suggestFrom
+	e.replace([]rune(candidate.Text))
+}
+
+// drawCompletion draws the completion popup aligned with the word being completed at x, y:
+// below its line, or above it when there is no room.
+func (e *Editor) drawCompletion(screen tcell.Screen, x, y int) {
+	if len(e.suggestions) == 0 {
+		return
+	}
+	screenWidth, screenHeight := screen.Size()
+
+	width := 0
+	for _, c := range e.suggestions {
+		width = max(width, uniseg.StringWidth(c.Text)+2+uniseg.StringWidth(c.Detail)+2)
+	}
+	width = min(width, screenWidth)
+	height := min(len(e.suggestions), completionRows)
+
+	top := y + 1
+	if top+height > screenHeight {
+		top = max(0, y-height)
+	}
+	left := max(0, min(x-1, screenWidth-width))
+
+	first := max(0, min(e.suggestion-height/2, len(e.suggestions)-height))
+	for row := 0; row < height; row++ {
+		c := e.suggestions[first+row]
+		style, detailStyle := e.theme.Input, e.theme.Input.Dim(true)
+		if first+row == e.suggestion {
+			style, detailStyle = e.theme.Selected, e.theme.Selected
+		}
+
+		for col := 0; col < width; col++ {
+			screen.SetContent(left+col, top+row, ' ', nil, style)
+		}
+		printCells(screen, c.Text, left+1, top+row, width-2, style)
+		detailX := left + width - 1 - uniseg.StringWidth(c.Detail)
+		if detailX > left+1+uniseg.StringWidth(c.Text) {
+			printCells(screen, c.Detail, detailX, top+row, width-2, detailStyle)
+		}
+	}
+}
+
+// printCells prints text from x, clipped to maxWidth cells.
+func printCells(screen tcell.Screen, text string, x, y, maxWidth int, style tcell.Style) {
+	used := 0
+	for _, r := range text {
+		w := max(1, uniseg.StringWidth(string(r)))
+		if used+w > maxWidth {
+			return
+		}
+		screen.SetContent(x+used, y, r, nil, style)
+		used += w
+	}
+}
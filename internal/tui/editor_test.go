@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/completion"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEditor(text string) *Editor {
+	theme, _ := NewTheme("", nil, true)
+	e := NewEditor(theme, 2, false)
+	e.SetText(text)
+	e.undo = nil
+
+	return e
+}
+
+func TestEditor_TypingAndUndo(t *testing.T) {
+	e := newTestEditor("")
+	for _, r := range "select 1" {
+		e.typeRune(r)
+	}
+	assert.Equal(t, "select 1", e.GetText())
+
+	e.Undo()
+	assert.Equal(t, "select ", e.GetText(), "a word is a single undo step")
+	e.Undo()
+	assert.Equal(t, "select", e.GetText())
+	e.Undo()
+	assert.Equal(t, "", e.GetText())
+	e.Undo()
+	assert.Equal(t, "", e.GetText())
+
+	e.Redo()
+	e.Redo()
+	assert.Equal(t, "select ", e.GetText())
+	assert.Equal(t, 7, e.cursor)
+
+	e.Insert("*")
+	e.Redo()
+	assert.Equal(t, "select *", e.GetText(), "a new change drops the redo history")
+}
+
+func TestEditor_Newline(t *testing.T) {
+	e := newTestEditor("  select count(")
+	e.Newline()
+	e.Insert("*")
+	e.moveTo(len(e.text), false)
+	e.Insert(")")
+	e.Newline()
+	assert.Equal(t, "  select count(\n    *)\n    ", e.GetText(), "indentation follows the previous line, deeper after a bracket")
+
+	e.Backspace()
+	assert.Equal(t, "  select count(\n    *)\n  ", e.GetText(), "backspace in leading spaces removes an indentation level")
+	e.Backspace()
+	e.Backspace()
+	assert.Equal(t, "  select count(\n    *)", e.GetText())
+}
+
+func TestEditor_IndentOutdent(t *testing.T) {
+	e := newTestEditor("select\na,\n\nb")
+	e.moveTo(0, false)
+	e.Indent()
+	assert.Equal(t, "  select\na,\n\nb", e.GetText())
+
+	e.moveTo(2, false)
+	e.moveTo(len(e.text), true)
+	e.Indent()
+	assert.Equal(t, "    select\n  a,\n\n  b", e.GetText(), "blank lines are not indented")
+	from, to := e.selection()
+	assert.Equal(t, "select\n  a,\n\n  b", string(e.text[from:to]), "selection follows the shifted text")
+
+	e.Outdent()
+	assert.Equal(t, "  select\na,\n\nb", e.GetText())
+	e.Outdent()
+	assert.Equal(t, "select\na,\n\nb", e.GetText())
+
+	e.Undo()
+	e.Undo()
+	assert.Equal(t, "    select\n  a,\n\n  b", e.GetText())
+}
+
+func TestEditor_Tabs(t *testing.T) {
+	theme, _ := NewTheme("", nil, true)
+	e := NewEditor(theme, 4, true)
+	e.Insert("select")
+	e.moveTo(0, false)
+	e.Indent()
+	assert.Equal(t, "\tselect", e.GetText())
+
+	row, col := e.position(len(e.text))
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 10, col, "tabs are displayed as wide as the indentation")
+	assert.Equal(t, 1, e.offsetAt(0, 4))
+
+	e.Outdent()
+	assert.Equal(t, "select", e.GetText())
+}
+
+func TestEditor_Statement(t *testing.T) {
+	e := newTestEditor("select 1;\nselect 2;\n")
+	e.moveTo(12, false)
+	assert.Equal(t, "select 2", e.Statement())
+
+	e.moveTo(0, false)
+	e.moveTo(6, true)
+	assert.Equal(t, "select", e.Statement(), "the selection wins over the statement under the cursor")
+}
+
+func TestEditor_Movement(t *testing.T) {
+	e := newTestEditor("select name\nfrom t\nwhere id = 1")
+	e.moveTo(10, false)
+	e.moveVertically(1, false)
+	assert.Equal(t, len("select name\nfrom t"), e.cursor, "the column is clamped to the shorter line")
+	e.moveVertically(1, false)
+	assert.Equal(t, len("select name\nfrom t\nwhere id ="), e.cursor, "the original column is remembered")
+
+	e.moveTo(len("select name\nfrom t\n")+8, false)
+	assert.Equal(t, len("select name\nfrom t\n")+6, e.wordLeft())
+	assert.Equal(t, len("select name\nfrom t\n")+12, e.wordRight(), "punctuation is skipped")
+
+	e.moveTo(len("select name\nfrom t\n")+3, false)
+	assert.Equal(t, len("select name\nfrom t\n"), e.home())
+}
+
+func TestEditor_InputHandler(t *testing.T) {
+	e := newTestEditor("select 1")
+	handler := e.InputHandler()
+	send := func(key tcell.Key, r rune, mod tcell.ModMask) {
+		handler(tcell.NewEventKey(key, r, mod), func(p tview.Primitive) {})
+	}
+
+	send(tcell.KeyLeft, 0, tcell.ModShift)
+	send(tcell.KeyRune, '2', tcell.ModNone)
+	assert.Equal(t, "select 2", e.GetText(), "typing replaces the selection")
+
+	send(tcell.KeyHome, 0, tcell.ModNone)
+	send(tcell.KeyRight, 0, tcell.ModCtrl|tcell.ModShift)
+	assert.Equal(t, "select", e.Statement())
+	send(tcell.KeyDelete, 0, tcell.ModNone)
+	assert.Equal(t, " 2", e.GetText())
+}
+
+type testMetadata struct{}
+
+func (testMetadata) Schemas() ([]string, bool) { return []string{"main"}, true }
+
+func (testMetadata) Tables(string) ([]string, bool) { return []string{"albums", "artists"}, true }
+
+func (testMetadata) Columns(_, table string) ([]internal.Column, bool) {
+	if table != "albums" {
+		return nil, true
+	}
+	return []internal.Column{{Name: "AlbumId", Type: "INTEGER", PrimaryKey: true}, {Name: "Title", Type: "TEXT"}}, true
+}
+
+func TestEditor_Completion(t *testing.T) {
+	e := newTestEditor("")
+	e.SetCompleteFunc(func(text string, offset int) (int, []completion.Candidate) {
+		return completion.Complete(text, offset, "main", testMetadata{})
+	})
+	handler := e.InputHandler()
+	send := func(key tcell.Key, r rune) {
+		handler(tcell.NewEventKey(key, r, tcell.ModNone), func(p tview.Primitive) {})
+	}
+	typeText := func(s string) {
+		for _, r := range s {
+			send(tcell.KeyRune, r)
+		}
+	}
+
+	typeText("select * from a")
+	assert.Equal(t, []string{"albums", "artists"}, suggestionTexts(e), "typing a name opens the popup")
+	send(tcell.KeyDown, 0)
+	send(tcell.KeyEnter, 0)
+	assert.Equal(t, "select * from artists", e.GetText())
+	assert.Empty(t, e.suggestions, "accepting closes the popup")
+
+	e.Undo()
+	assert.Equal(t, "select * from a", e.GetText(), "accepting is a single undo step")
+
+	e.SetText("select  from albums")
+	e.moveTo(len("select "), false)
+	e.Complete()
+	assert.Equal(t, []string{"AlbumId", "Title"}, suggestionTexts(e)[:2], "columns of the referenced tables come first")
+	send(tcell.KeyEsc, 0)
+	assert.Empty(t, e.suggestions)
+
+	typeText(" ")
+	assert.Empty(t, e.suggestions, "an empty word does not open the popup")
+	send(tcell.KeyBackspace2, 0)
+	typeText("t")
+	assert.Equal(t, "Title", suggestionTexts(e)[0])
+	send(tcell.KeyBackspace2, 0)
+	assert.True(t, e.completing, "deleting keeps the popup open")
+	send(tcell.KeyLeft, 0)
+	assert.False(t, e.completing, "moving the cursor closes the popup")
+}
+
+func suggestionTexts(e *Editor) []string {
+	var texts []string
+	for _, c := range e.suggestions {
+		texts = append(texts, c.Text)
+	}
+	return texts
+}
@@ -4,12 +4,27 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/sqlparse"
 )
 
+// sourceSelected switches to the data source. Leaving one with a transaction open needs confirmation, as the
+// transaction stays open until switching back to end it.
 func (tui *TUI) sourceSelected(_ int, mainText string, _ string, _ rune) {
-	err := tui.dc.Switch(mainText)
+	if alias := tui.dc.CurrentAlias(); alias != mainText && tui.inTransaction(alias) {
+		viewer := NewViewer()
+		viewer.Write(fmt.Sprintf("The transaction on %s stays open while %s is used.\nSwitch back to %s to commit or roll it back.",
+			alias, mainText, alias), tui.theme.Warning)
+		tui.confirm("Switch to "+mainText, viewer, func() { tui.switchSource(mainText) })
+		return
+	}
+	tui.switchSource(mainText)
+}
+
+// switchSource makes the data source with the alias the current one and loads its schemas.
+func (tui *TUI) switchSource(alias string) {
+	err := tui.dc.Switch(alias)
 	if err != nil {
 		tui.showError(err)
 		return
@@ -43,24 +58,35 @@ func (tui *TUI) schemaSelected(_ int, mainText string, _ string, _ rune) {
 }
 
 func (tui *TUI) tableSelected(_ int, mainText string, secondaryText string, _ rune) {
-	data, err := tui.dc.Current().PreviewTable(secondaryText, mainText)
+	result, err := tui.previewTable(secondaryText, mainText)
 	if err != nil {
 		tui.showError(err)
 		return
 	}
 
-	tui.showData(mainText, data)
+	result.label = mainText
+	tui.showData(result)
 	tui.setFocus(tui.PreviewTable)
 }
 
-func (tui *TUI) queryExecuted(key tcell.Key) {
-	// The done func also fires on Esc, Up, Down, and Backtab — only Enter runs the query.
-	if key != tcell.KeyEnter {
-		return
+// executeQuery runs the selection or the statement under the cursor of the Query editor, or all its statements.
+func (tui *TUI) executeQuery(all bool) {
+	var statements []string
+	if all {
+		for _, s := range sqlparse.Split(tui.QueryEditor.GetText()) {
+			statements = append(statements, s.Text)
+		}
+	} else if s := tui.QueryEditor.Statement(); s != "" {
+		statements = append(statements, s)
 	}
+	tui.runStatements("query", statements)
+}
 
-	query := strings.TrimSpace(tui.QueryInput.GetText())
-	if query == "" {
+// runStatements runs the statements one after another in the selected schema and shows the result of the last one
+// in the preview under the label. Execution stops at the first failing statement. Statements deleting, updating or
+// dropping data wholesale need confirmation first.
+func (tui *TUI) runStatements(label string, statements []string) {
+	if len(statements) == 0 {
 		return
 	}
 
@@ -70,12 +96,46 @@ func (tui *TUI) queryExecuted(key tcell.Key) {
 		return
 	}
 
+	if guarded := tui.guard(schema, statements); len(guarded) > 0 {
+		viewer := NewViewer()
+		tui.writeGuarded(viewer, guarded, len(statements))
+		tui.confirm("Run dangerous statements?", viewer, func() {
+			tui.runGuarded(label, schema, statements)
+		})
+		return
+	}
+	tui.runGuarded(label, schema, statements)
+}
+
+// runGuarded runs the statements once they passed the guard.
+func (tui *TUI) runGuarded(label, schema string, statements []string) {
 	tui.showMessage("Executing...")
-	data, err := tui.dc.Current().Query(schema, query)
-	if err != nil {
-		tui.showError(err)
+	var (
+		data [][]*string
+		err  error
+	)
+	for i, query := range statements {
+		started := time.Now()
+		data, err = tui.dc.Current().Query(schema, query)
+		tui.recordQuery(schema, query, started, data, err)
+		if err != nil {
+			if len(statements) > 1 {
+				err = fmt.Errorf("statement %d of %d: %w", i+1, len(statements), err)
+			}
+			tui.showError(err)
+			return
+		}
+	}
+
+	tui.showData(resultTab{label: label, schema: schema, sql: statements[len(statements)-1], data: data})
+	if len(statements) > 1 {
+		tui.showMessage(fmt.Sprintf("%d statements executed successfully!", len(statements)))
 	} else {
-		tui.showData("query", data)
-		tui.showMessage(fmt.Sprintf("Query \"%s\" executed successfully!", query))
+		tui.showMessage(fmt.Sprintf("Query \"%s\" executed successfully!", singleLine(statements[0])))
 	}
 }
+
+// singleLine collapses whitespace, including line breaks, so that multi-line SQL fits a message line.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
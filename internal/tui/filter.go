@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/sqlgen"
+	"github.com/kenanbek/dbui/internal/sqlparse"
+	"github.com/rivo/tview"
+)
+
+const filterPage = "filter"
+
+// previewTab returns the active tab if it previews a table of the current data source whose query can be rebuilt to
+// filter, sort or page it: rebuilding replaces the rows, so tabs with pending changes are refused.
+func (tui *TUI) previewTab() (*resultTab, error) {
+	tab := tui.currentTab()
+	if tab.table == "" || tab.sql != "" {
+		return nil, errors.New("only table previews can be filtered, sorted and paged")
+	}
+	if tab.alias != tui.dc.CurrentAlias() {
+		return nil, fmt.Errorf("the result comes from %s, switch to it first", tab.alias)
+	}
+	if tab.changes != nil && tab.changes.Len() > 0 {
+		return nil, fmt.Errorf("the tab has %s pending: apply or discard them first", pendingChanges(tab.changes.Len()))
+	}
+
+	return tab, nil
+}
+
+// applyPreview shows the first page of the table of the tab again, filtered and sorted as given. The tab is left as
+// it is when the query fails, e.g. on a mistyped condition.
+func (tui *TUI) applyPreview(tab *resultTab, preview internal.Preview) {
+	preview.After, preview.Before, preview.Offset = nil, nil, 0
+	tui.showPage(tab, preview, 1)
+}
+
+// showFilterBar asks for the condition of the WHERE clause narrowing the preview. An empty condition shows every row.
+func (tui *TUI) showFilterBar() {
+	tab, err := tui.previewTab()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+
+	input := tview.NewInputField().SetText(tab.preview.Where).SetFieldStyle(tui.theme.Input).
+		SetLabel("WHERE ").SetLabelStyle(tcell.StyleDefault.Foreground(tui.theme.Title))
+	input.SetDoneFunc(func(key tcell.Key) {
+		tui.hideOverlay(filterPage)
+		if key == tcell.KeyEnter {
+			preview := tab.preview
+			preview.Where = strings.TrimSpace(input.GetText())
+			tui.applyPreview(tab, preview)
+		}
+	})
+	input.SetBorder(true).SetTitle(fmt.Sprintf("Filter %s [ Enter: apply · Esc: cancel ]", tab.table)).
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	tui.showOverlay(filterPage, input, 100, 3)
+}
+
+// quickFilter narrows the preview to the rows whose value in the selected column compares to the selected value
+// with the operator, in addition to the current filter.
+func (tui *TUI) quickFilter(operator string) {
+	row, column, ok := tui.selectedCell()
+	if !ok {
+		tui.showWarning("no cell to filter by")
+		return
+	}
+	tab, err := tui.previewTab()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+
+	dialect := sqlgen.DialectFor(tui.typeOf(tab.alias))
+	preview := tab.preview
+	preview.Where = and(preview.Where, dialect.Condition(tui.resultColumns()[column], operator, tab.data[row][column]))
+	tui.applyPreview(tab, preview)
+}
+
+// and joins the conditions with AND. The first one is parenthesized if it has an OR, which binds looser than AND.
+func and(where, condition string) string {
+	if where == "" {
+		return condition
+	}
+	for _, t := range sqlparse.Tokenize(where) {
+		if t.Kind == sqlparse.Keyword && strings.EqualFold(t.Text, "or") {
+			where = "(" + where + ")"
+			break
+		}
+	}
+
+	return where + " AND " + condition
+}
+
+// toggleSort sorts the preview by the selected column, ascending first, then descending, then unsorted again.
+func (tui *TUI) toggleSort() {
+	_, column, ok := tui.selectedCell()
+	if !ok {
+		tui.showWarning("no column to sort by")
+		return
+	}
+	tab, err := tui.previewTab()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+
+	name := tui.resultColumns()[column]
+	preview := tab.preview
+	switch {
+	case len(preview.OrderBy) == 0 || preview.OrderBy[0].Column != name:
+		preview.OrderBy = []internal.Order{{Column: name}}
+	case !preview.OrderBy[0].Descending:
+		preview.OrderBy = []internal.Order{{Column: name, Descending: true}}
+	default:
+		preview.OrderBy = nil
+	}
+	tui.applyPreview(tab, preview)
+	tui.PreviewTable.Select(1, slices.Index(tab.columns, column))
+}
+
+// previewTitle describes the filter and the sort order of the preview for the title, e.g. " · WHERE a = 1 · ORDER BY b DESC".
+func previewTitle(preview internal.Preview) string {
+	title := ""
+	if preview.Where != "" {
+		title += " · WHERE " + preview.Where
+	}
+	if len(preview.OrderBy) > 0 {
+		terms := make([]string, len(preview.OrderBy))
+		for i, o := range preview.OrderBy {
+			terms[i] = o.Column
+			if o.Descending {
+				terms[i] += " DESC"
+			}
+		}
+		title += " · ORDER BY " + strings.Join(terms, ", ")
+	}
+
+	return tview.Escape(title)
+}
+
+// sortArrow returns the arrow marking the header of a column the preview is sorted by, or an empty string.
+func sortArrow(preview internal.Preview, column string) string {
+	i := slices.IndexFunc(preview.OrderBy, func(o internal.Order) bool { return o.Column == column })
+	switch {
+	case i < 0:
+		return ""
+	case preview.OrderBy[i].Descending:
+		return " ▼"
+	default:
+		return " ▲"
+	}
+}
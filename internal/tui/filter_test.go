@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/sqlgen"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnd(t *testing.T) {
+	assert.Equal(t, `"a" = '1'`, and("", `"a" = '1'`))
+	assert.Equal(t, `b > 2 AND "a" = '1'`, and("b > 2", `"a" = '1'`))
+	assert.Equal(t, `(b > 2 or c) AND "a" = '1'`, and("b > 2 or c", `"a" = '1'`))
+	assert.Equal(t, `b = 'x or y' AND "a" = '1'`, and("b = 'x or y'", `"a" = '1'`), "OR in strings does not count")
+}
+
+func TestTUI_FilterAndSort(t *testing.T) {
+	data := [][]*string{{sptr("id"), sptr("name")}, {sptr("1"), sptr("ann")}, {sptr("2"), nil}}
+	ds := &fakeDataSource{data: data}
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.dc = fakeController{alias: "my", sources: [][]string{{"my", "mysql"}}, ds: ds}
+	*tui.currentTab() = resultTab{label: "users", alias: "my", schema: "app", table: "users", data: data, row: 1}
+	tui.renderTab()
+
+	tui.PreviewTable.Select(1, 1)
+	tui.quickFilter(sqlgen.Equal)
+	tui.PreviewTable.Select(2, 1)
+	tui.quickFilter(sqlgen.NotEqual)
+	tui.toggleSort()
+	tui.toggleSort()
+
+	where := "`name` = 'ann' AND `name` IS NOT NULL"
+	assert.Equal(t, []internal.Preview{
+		{Where: "`name` = 'ann'"},
+		{Where: where},
+		{Where: where, OrderBy: []internal.Order{{Column: "name"}}},
+		{Where: where, OrderBy: []internal.Order{{Column: "name", Descending: true}}},
+	}, ds.previews)
+	assert.Equal(t, "name ▼", tui.PreviewTable.GetCell(0, 1).Text)
+	assert.Contains(t, tui.PreviewTable.GetTitle(), "· WHERE "+where+" · ORDER BY name DESC")
+
+	tui.toggleSort()
+	assert.Nil(t, tui.currentTab().preview.OrderBy, "the third toggle stops sorting")
+
+	tui.currentTab().sql = "SELECT * FROM users"
+	_, err := tui.previewTab()
+	assert.EqualError(t, err, "only table previews can be filtered, sorted and paged")
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/fuzzy"
+	"github.com/kenanbek/dbui/internal/layout"
+	"github.com/kenanbek/dbui/internal/metadata"
+	"github.com/rivo/tview"
+)
+
+const finderPage = "finder"
+
+// finderLimit is the number of best matches the finder lists.
+const finderLimit = 200
+
+// showFinder opens the fuzzy search over the tables, views and columns of every schema of the current data source.
+// The index is built in the background and the list grows while schemas are indexed.
+func (tui *TUI) showFinder() {
+	cache := tui.currentMetadata()
+	var shown []metadata.Object
+
+	input := tview.NewInputField().SetLabel("> ").SetFieldStyle(tui.theme.Input).SetLabelStyle(tcell.StyleDefault.Foreground(tui.theme.Title))
+	list := tview.NewList().SetSecondaryTextStyle(tui.theme.Secondary).
+		SetMainTextStyle(tui.theme.Text).SetSelectedStyle(tui.theme.Selected)
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	flex.SetBorder(true).SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	filter := func() {
+		objects, complete := cache.Index()
+		title := fmt.Sprintf("Find in %s", tui.dc.CurrentAlias())
+		if !complete {
+			title += " (indexing…)"
+		}
+		flex.SetTitle(title)
+
+		current := list.GetCurrentItem()
+		matches := fuzzy.Filter(input.GetText(), len(objects), func(i int) string { return objects[i].Name() })
+		shown = shown[:0]
+		list.Clear()
+		for _, i := range matches[:min(len(matches), finderLimit)] {
+			shown = append(shown, objects[i])
+			list.AddItem(tview.Escape(objects[i].Name()), objects[i].Kind.String(), 0, nil)
+		}
+		list.SetCurrentItem(current)
+	}
+	closeFinder := func() {
+		tui.refreshOverlay = nil
+		tui.hideOverlay(finderPage)
+	}
+	choose := func(index int) {
+		closeFinder()
+		if index < len(shown) {
+			tui.previewObject(shown[index])
+		}
+	}
+
+	input.SetChangedFunc(func(string) {
+		list.SetCurrentItem(0)
+		filter()
+	})
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) { choose(index) })
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			list.InputHandler()(event, func(tview.Primitive) {})
+		case tcell.KeyEnter:
+			choose(list.GetCurrentItem())
+		case tcell.KeyEsc:
+			closeFinder()
+		default:
+			return event
+		}
+		return nil
+	})
+
+	tui.refreshOverlay = filter
+	filter()
+	tui.showOverlay(finderPage, flex, 100, 20)
+}
+
+// previewObject previews the table or view found by the finder, selecting the column of column objects.
+func (tui *TUI) previewObject(o metadata.Object) {
+	result, err := tui.previewTable(o.Schema, o.Table)
+	if err != nil {
+		tui.showError(err)
+		return
+	}
+
+	result.label = o.Table
+	if o.Kind == metadata.Column {
+		result.alias = tui.dc.CurrentAlias()
+		result.column = tui.revealColumn(&result, o.Column)
+	}
+	tui.showData(result)
+	tui.setFocus(tui.PreviewTable)
+}
+
+// revealColumn returns the place of the column among the shown columns of the result, showing it first if the
+// remembered layout of the table hides it.
+func (tui *TUI) revealColumn(tab *resultTab, column string) int {
+	if len(tab.data) == 0 {
+		return 0
+	}
+	l := tui.layoutOf(tab)
+	if slices.Contains(l.Hidden, column) && tui.layouts != nil {
+		l.Hide(column, false)
+		if err := tui.layouts.Set(layout.Key(tab.alias, tab.schema, tab.table), l); err != nil {
+			tui.showWarning(fmt.Sprintf("Failed to save the column layout: %v", err))
+		}
+	}
+
+	names := make([]string, len(tab.data[0]))
+	for i, name := range tab.data[0] {
+		if name != nil {
+			names[i] = *name
+		}
+	}
+
+	return max(0, slices.Index(l.Columns(names), slices.Index(names, column)))
+}
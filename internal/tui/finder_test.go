@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kenanbek/dbui/internal/layout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTUI_RevealColumn(t *testing.T) {
+	store, err := layout.Open(filepath.Join(t.TempDir(), "layouts.json"))
+	require.NoError(t, err)
+	key := layout.Key("my", "app", "users")
+	require.NoError(t, store.Set(key, layout.Layout{Order: []string{"email"}, Hidden: []string{"name", "email"}}))
+	tui := newTestTUI(t)
+	tui.layouts = store
+	tab := &resultTab{alias: "my", schema: "app", table: "users", data: [][]*string{{sptr("id"), sptr("name"), sptr("email")}}}
+
+	assert.Equal(t, 0, tui.revealColumn(tab, "id"))
+	assert.Equal(t, 0, tui.revealColumn(tab, "email"), "the column is placed as the layout orders it")
+	saved, _ := store.Get(key)
+	assert.Equal(t, []string{"name"}, saved.Hidden, "the hidden column is shown again")
+	assert.Equal(t, 0, tui.revealColumn(&resultTab{}, "id"))
+}
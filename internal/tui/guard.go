@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/kenanbek/dbui/internal/sqlparse"
+)
+
+// guardedStatement is a statement which needs confirmation before running.
+type guardedStatement struct {
+	sqlparse.Classification
+	// index is the position of the statement among the ones run together.
+	index int
+	text  string
+	// rows is the estimated number of rows of the table the statement acts on, or -1 when unknown.
+	rows int64
+}
+
+// StatementClasses returns the names of the classes of statements which can need confirmation before running.
+func StatementClasses() []string {
+	var names []string
+	for _, c := range sqlparse.Classes {
+		names = append(names, string(c))
+	}
+	return names
+}
+
+// confirmedClasses returns the classes of statements which need confirmation on the current data source: the
+// configured ones, or else every class.
+func (tui *TUI) confirmedClasses() []sqlparse.Class {
+	if tui.ac == nil {
+		return sqlparse.Classes
+	}
+	dsc, ok := tui.ac.DataSourceConfigs()[tui.dc.CurrentAlias()]
+	if !ok || dsc.Confirm() == nil {
+		return sqlparse.Classes
+	}
+
+	classes := []sqlparse.Class{}
+	for _, name := range dsc.Confirm() {
+		classes = append(classes, sqlparse.Class(name))
+	}
+	return classes
+}
+
+// guard returns the statements which need confirmation before running on the current data source, together with the
+// estimated number of rows of the tables they act on. The estimates come from the statistics of the data source, as
+// counting the rows of a large table would keep the interface waiting.
+func (tui *TUI) guard(schema string, statements []string) []guardedStatement {
+	classes := tui.confirmedClasses()
+
+	var guarded []guardedStatement
+	for i, statement := range statements {
+		c := sqlparse.Classify(statement)
+		if c.Class == "" || !slices.Contains(classes, c.Class) {
+			continue
+		}
+
+		g := guardedStatement{Classification: c, index: i, text: statement, rows: -1}
+		if c.Name != "" {
+			g.rows = tui.estimateRows(cmp.Or(c.Schema, schema), c.Name)
+		}
+		guarded = append(guarded, g)
+	}
+
+	return guarded
+}
+
+// estimateRows estimates the rows of the table, or returns -1 when there is no estimate.
+func (tui *TUI) estimateRows(schema, table string) int64 {
+	n, err := tui.dc.Current().EstimateRows(schema, table)
+	if err != nil {
+		return -1
+	}
+
+	return n
+}
+
+// effect describes what the statement does to its table, e.g. "deletes every row of users (~120 rows)".
+func (g guardedStatement) effect() string {
+	var effect string
+	switch g.Class {
+	case sqlparse.DeleteAll, sqlparse.Truncate:
+		effect = "deletes every row of " + g.Table
+	case sqlparse.UpdateAll:
+		effect = "updates every row of " + g.Table
+	case sqlparse.Drop:
+		effect = "drops " + g.Table
+	case sqlparse.Alter:
+		effect = "alters " + g.Table
+	}
+	if g.Table == "" {
+		return effect + "an object"
+	}
+	if g.rows < 0 {
+		return effect + " (rows unknown)"
+	}
+
+	return fmt.Sprintf("%s (~%s)", effect, countRows(int(g.rows)))
+}
+
+// writeGuarded writes the statements needing confirmation to the viewer, each with what it does.
+func (tui *TUI) writeGuarded(viewer *Viewer, guarded []guardedStatement, total int) {
+	for i, g := range guarded {
+		if i > 0 {
+			viewer.Write("\n\n", tui.theme.Text)
+		}
+		heading := "This statement"
+		if total > 1 {
+			heading = fmt.Sprintf("Statement %d of %d", g.index+1, total)
+		}
+		viewer.Write(fmt.Sprintf("%s %s:\n", heading, g.effect()), tui.theme.Warning)
+		tui.writeSQL(viewer, g.text)
+	}
+}
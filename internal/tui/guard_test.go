@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/sqlparse"
+	"github.com/stretchr/testify/assert"
+)
+
+// estimateDataSource estimates a fixed number of rows, recording the tables estimated as schema.table. Tables named
+// "unknown" have no estimate.
+type estimateDataSource struct {
+	fakeDataSource
+	rows   int64
+	tables []string
+}
+
+func (d *estimateDataSource) EstimateRows(schema, table string) (int64, error) {
+	d.tables = append(d.tables, schema+"."+table)
+	if table == "unknown" {
+		return -1, nil
+	}
+	return d.rows, nil
+}
+
+// confirmConfig configures the statement classes needing confirmation on a single data source.
+type confirmConfig struct {
+	internal.AppConfig
+	internal.DataSourceConfig
+	alias   string
+	confirm []string
+}
+
+func (c confirmConfig) DataSourceConfigs() map[string]internal.DataSourceConfig {
+	return map[string]internal.DataSourceConfig{c.alias: c}
+}
+
+func (c confirmConfig) Confirm() []string {
+	return c.confirm
+}
+
+func TestTUI_Guard(t *testing.T) {
+	ds := &estimateDataSource{rows: 120}
+	tui := &TUI{dc: fakeController{alias: "pg", ds: ds}}
+	statements := []string{"select 1", "delete from users", "drop table if exists public.orders", "update users set a = 1 where id = 2"}
+
+	guarded := tui.guard("public", statements)
+	if assert.Len(t, guarded, 2) {
+		assert.Equal(t, 1, guarded[0].index)
+		assert.Equal(t, "deletes every row of users (~120 rows)", guarded[0].effect())
+		assert.Equal(t, 2, guarded[1].index)
+		assert.Equal(t, "drops public.orders (~120 rows)", guarded[1].effect())
+	}
+	assert.Equal(t, []string{"public.users", "public.orders"}, ds.tables, "qualified names are looked up in their schema")
+
+	tui.ac = confirmConfig{alias: "pg", confirm: []string{"drop"}}
+	guarded = tui.guard("public", statements)
+	if assert.Len(t, guarded, 1, "only the configured classes need confirmation") {
+		assert.Equal(t, sqlparse.Drop, guarded[0].Class)
+	}
+
+	tui.ac = confirmConfig{alias: "pg", confirm: []string{}}
+	assert.Empty(t, tui.guard("public", statements), "an empty list turns confirmation off")
+
+	tui.ac = confirmConfig{alias: "other"}
+	assert.Len(t, tui.guard("public", statements), 2, "unconfigured data sources confirm every class")
+}
+
+func TestTUI_GuardWithoutEstimate(t *testing.T) {
+	ds := &estimateDataSource{}
+	tui := &TUI{dc: fakeController{alias: "my", ds: ds}}
+
+	guarded := tui.guard("shop", []string{"truncate `audit`.`unknown`"})
+	if assert.Len(t, guarded, 1) {
+		assert.Equal(t, "deletes every row of `audit`.`unknown` (rows unknown)", guarded[0].effect())
+	}
+	assert.Equal(t, []string{"audit.unknown"}, ds.tables, "names are looked up without quotes")
+}
+
+func TestGuardedStatement_Effect(t *testing.T) {
+	g := guardedStatement{Classification: sqlparse.Classification{Class: sqlparse.UpdateAll, Table: "users"}, rows: 1}
+	assert.Equal(t, "updates every row of users (~1 row)", g.effect())
+
+	g.Class, g.rows = sqlparse.Truncate, -1
+	assert.Equal(t, "deletes every row of users (rows unknown)", g.effect())
+
+	g.Class, g.Table = sqlparse.Drop, ""
+	assert.Equal(t, "drops an object", g.effect())
+}
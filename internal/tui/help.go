@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rivo/uniseg"
+)
+
+const helpPage = "help"
+
+// helpKeyWidth is the width of the key column of the help screen; longer key lists push the description further.
+const helpKeyWidth = 22
+
+// scopeTitles names the key scopes in the help screen.
+var scopeTitles = map[KeyScope]string{
+	ScopeGlobal:   "Global",
+	ScopeSources:  "Sources",
+	ScopeSchemas:  "Schemas",
+	ScopeTables:   "Tables",
+	ScopeSnippets: "Snippets",
+	ScopePreview:  "Preview",
+	ScopeQuery:    "Query",
+}
+
+// helpSection lists the actions of a scope in the help screen.
+type helpSection struct {
+	scope   KeyScope
+	actions []keyAction
+}
+
+// helpSections returns the actions of the scope, followed by the global ones, whose keys, description or name
+// contain the query, ignoring case. Sections without matching actions are left out.
+func (km *KeyMap) helpSections(scope KeyScope, query string) []helpSection {
+	scopes := []KeyScope{scope}
+	if scope != ScopeGlobal {
+		scopes = append(scopes, ScopeGlobal)
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	var sections []helpSection
+	for _, s := range scopes {
+		section := helpSection{scope: s}
+		for _, a := range keyActions {
+			text := strings.ToLower(km.Label(a.op) + " " + a.help + " " + a.name)
+			if a.scope == s && strings.Contains(text, query) {
+				section.actions = append(section.actions, a)
+			}
+		}
+		if len(section.actions) > 0 {
+			sections = append(sections, section)
+		}
+	}
+
+	return sections
+}
+
+// writeHelp writes the sections to the viewer: a line per action with its keys, description and name.
+func (tui *TUI) writeHelp(viewer *Viewer, sections []helpSection) {
+	viewer.Clear()
+	if len(sections) == 0 {
+		viewer.Write("No key matches the search", tui.theme.Secondary)
+		return
+	}
+
+	heading := tcell.StyleDefault.Foreground(tui.theme.Title).Bold(true)
+	for i, section := range sections {
+		if i > 0 {
+			viewer.Write("\n\n", tui.theme.Text)
+		}
+		viewer.Write(scopeTitles[section.scope], heading)
+		for _, a := range section.actions {
+			keys := tui.keys.Label(a.op)
+			viewer.Write("\n  "+keys+strings.Repeat(" ", max(1, helpKeyWidth-uniseg.StringWidth(keys))), tui.theme.Keyword)
+			viewer.Write(a.help+" ", tui.theme.Text)
+			viewer.Write(fmt.Sprintf("(%s)", a.name), tui.theme.Secondary)
+		}
+	}
+}
+
+// showHelp opens the help screen listing the key bindings of the focused view and the global ones, as currently
+// mapped. Typing searches the keys, descriptions and action names.
+func (tui *TUI) showHelp() {
+	scope := tui.focusedScope()
+
+	input := tview.NewInputField().SetLabel("/ ").SetFieldStyle(tui.theme.Input).SetLabelStyle(tcell.StyleDefault.Foreground(tui.theme.Title))
+	viewer := NewViewer()
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(viewer, 0, 1, false)
+	flex.SetBorder(true).SetTitle(fmt.Sprintf("Help: %s [ Esc: close ]", scopeTitles[scope])).
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	input.SetChangedFunc(func(text string) {
+		tui.writeHelp(viewer, tui.keys.helpSections(scope, text))
+	})
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			viewer.InputHandler()(event, func(tview.Primitive) {})
+		case tcell.KeyEsc:
+			tui.hideOverlay(helpPage)
+		default:
+			return event
+		}
+		return nil
+	})
+
+	tui.writeHelp(viewer, tui.keys.helpSections(scope, ""))
+	tui.showOverlay(helpPage, flex, 100, 40)
+}
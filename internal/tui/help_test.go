@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func names(sections []helpSection) map[KeyScope][]string {
+	names := map[KeyScope][]string{}
+	for _, s := range sections {
+		for _, a := range s.actions {
+			names[s.scope] = append(names[s.scope], a.name)
+		}
+	}
+	return names
+}
+
+func TestKeyMap_HelpSections(t *testing.T) {
+	km, err := NewKeyMap(map[string]string{"copyRow": "Ctrl-Y"})
+	require.NoError(t, err)
+
+	sections := km.helpSections(ScopePreview, "")
+	require.Len(t, sections, 2)
+	assert.Equal(t, ScopePreview, sections[0].scope, "the focused view comes first")
+	assert.Equal(t, ScopeGlobal, sections[1].scope)
+
+	assert.Equal(t, map[KeyScope][]string{ScopePreview: {"copyCell", "copyRow", "copyAll", "copyFormat"}},
+		names(km.helpSections(ScopePreview, " COPY ")))
+	assert.Equal(t, map[KeyScope][]string{ScopePreview: {"copyRow"}}, names(km.helpSections(ScopePreview, "ctrl-y")),
+		"remapped keys are searched")
+	assert.Equal(t, map[KeyScope][]string{ScopeGlobal: {"find"}}, names(km.helpSections(ScopeTables, "find")))
+	assert.Len(t, km.helpSections(ScopeGlobal, ""), 1)
+	assert.Empty(t, km.helpSections(ScopeQuery, "nothing like it"))
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/fuzzy"
+	"github.com/kenanbek/dbui/internal/history"
+	"github.com/rivo/tview"
+)
+
+const historyPage = "history"
+
+// historyRecall tracks the Up/Down browsing through the history in the Query editor.
+type historyRecall struct {
+	// index is the position of the recalled entry among the entries of the data source, or -1 when not browsing.
+	index int
+	// draft is the editor text before browsing started, restored when browsing past the newest entry.
+	draft string
+	// shown is the text of the recalled entry; browsing starts over once the text is edited.
+	shown string
+}
+
+// recordQuery adds an executed statement to the history.
+func (tui *TUI) recordQuery(schema, query string, started time.Time, data [][]*string, err error) {
+	entry := history.Entry{
+		Alias:    tui.dc.CurrentAlias(),
+		Schema:   schema,
+		Query:    query,
+		Time:     started,
+		Duration: time.Since(started),
+		Rows:     max(0, len(data)-1),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if err := tui.history.Add(entry); err != nil {
+		tui.showWarning(fmt.Sprintf("Failed to save the query history: %v", err))
+	}
+}
+
+// recallHistory replaces the Query editor text with an older (step 1) or newer (step -1) query of the current
+// data source. It reports false when there is nothing to recall, so that the key moves the cursor instead.
+func (tui *TUI) recallHistory(step int) bool {
+	r := &tui.recall
+	if r.index >= 0 && tui.QueryEditor.GetText() != r.shown {
+		r.index = -1
+	}
+
+	entries := tui.history.Entries(tui.dc.CurrentAlias())
+	next := r.index + step
+	switch {
+	case next >= len(entries) || next < -1:
+		return false
+	case step > 0 && !tui.QueryEditor.onFirstLine(), step < 0 && !tui.QueryEditor.onLastLine():
+		return false
+	case r.index == -1:
+		r.draft = tui.QueryEditor.GetText()
+	}
+
+	r.index = next
+	if next == -1 {
+		tui.QueryEditor.SetText(r.draft)
+		return true
+	}
+	r.shown = entries[next].Query
+	tui.QueryEditor.SetText(r.shown)
+
+	return true
+}
+
+// historyLabel describes a history entry in the secondary line of the history search.
+func historyLabel(e history.Entry) string {
+	label := fmt.Sprintf("%s · %s · %s · %s", e.Time.Local().Format("2006-01-02 15:04:05"), e.Alias, e.Schema,
+		e.Duration.Round(time.Millisecond))
+	if e.Failed() {
+		return fmt.Sprintf("%s · error: %s", label, singleLine(e.Error))
+	}
+	return fmt.Sprintf("%s · %d rows", label, e.Rows)
+}
+
+// showHistory opens the fuzzy search over the query history. The search covers the current data source;
+// Ctrl-S switches between the current and all data sources.
+func (tui *TUI) showHistory() {
+	var (
+		allSources bool
+		shown      []history.Entry
+	)
+
+	input := tview.NewInputField().SetLabel("> ").SetFieldStyle(tui.theme.Input).SetLabelStyle(tcell.StyleDefault.Foreground(tui.theme.Title))
+	list := tview.NewList().SetSecondaryTextStyle(tui.theme.Secondary).
+		SetMainTextStyle(tui.theme.Text).SetSelectedStyle(tui.theme.Selected)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	layout.SetBorder(true).SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	filter := func() {
+		alias, scope := tui.dc.CurrentAlias(), tui.dc.CurrentAlias()
+		if allSources {
+			alias, scope = "", "all sources"
+		}
+		layout.SetTitle(fmt.Sprintf("History: %s [ Ctrl-S: toggle all sources ]", scope))
+
+		entries := tui.history.Entries(alias)
+		shown = shown[:0]
+		list.Clear()
+		for _, i := range fuzzy.Filter(input.GetText(), len(entries), func(i int) string { return entries[i].Query }) {
+			shown = append(shown, entries[i])
+			list.AddItem(singleLine(entries[i].Query), historyLabel(entries[i]), 0, nil)
+		}
+	}
+	choose := func(index int) {
+		tui.hideOverlay(historyPage)
+		if index < len(shown) {
+			tui.QueryEditor.SetText(shown[index].Query)
+			tui.recall.index = -1
+			tui.App.SetFocus(tui.QueryEditor)
+		}
+	}
+
+	input.SetChangedFunc(func(string) { filter() })
+	list.SetSelectedFunc(func(index int, _, _ string, _ rune) { choose(index) })
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			list.InputHandler()(event, func(tview.Primitive) {})
+		case tcell.KeyEnter:
+			choose(list.GetCurrentItem())
+		case tcell.KeyEsc:
+			tui.hideOverlay(historyPage)
+		case tcell.KeyCtrlS:
+			allSources = !allSources
+			filter()
+		default:
+			return event
+		}
+		return nil
+	})
+
+	filter()
+	tui.showOverlay(historyPage, layout, 100, 20)
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeController struct {
+	internal.DataController
+	alias   string
+	sources [][]string
+	ds      internal.DataSource
+}
+
+func (c fakeController) Current() internal.DataSource {
+	return c.ds
+}
+
+func (c fakeController) List() [][]string {
+	return c.sources
+}
+
+func (c fakeController) CurrentAlias() string {
+	return c.alias
+}
+
+func TestTUI_RecallHistory(t *testing.T) {
+	h, err := history.Open("", history.DefaultLimit)
+	require.NoError(t, err)
+	for _, e := range []history.Entry{
+		{Alias: "pg", Query: "select 1"},
+		{Alias: "my", Query: "show tables"},
+		{Alias: "pg", Query: "select 2\nfrom t"},
+	} {
+		require.NoError(t, h.Add(e))
+	}
+
+	tui := &TUI{dc: fakeController{alias: "pg"}, history: h, QueryEditor: newTestEditor("draft"), recall: historyRecall{index: -1}}
+
+	assert.True(t, tui.recallHistory(1))
+	assert.Equal(t, "select 2\nfrom t", tui.QueryEditor.GetText(), "the newest query of the current source comes first")
+	assert.False(t, tui.recallHistory(1), "the cursor is not on the first line")
+
+	tui.QueryEditor.moveTo(0, false)
+	assert.True(t, tui.recallHistory(1))
+	assert.Equal(t, "select 1", tui.QueryEditor.GetText(), "queries of other sources are skipped")
+	assert.False(t, tui.recallHistory(1), "there is nothing older")
+
+	assert.True(t, tui.recallHistory(-1))
+	assert.Equal(t, "select 2\nfrom t", tui.QueryEditor.GetText())
+	assert.True(t, tui.recallHistory(-1))
+	assert.Equal(t, "draft", tui.QueryEditor.GetText(), "browsing past the newest query restores the draft")
+	assert.False(t, tui.recallHistory(-1))
+
+	assert.True(t, tui.recallHistory(1))
+	tui.QueryEditor.Insert(" where")
+	tui.QueryEditor.moveTo(0, false)
+	assert.True(t, tui.recallHistory(1))
+	assert.Equal(t, "select 2\nfrom t", tui.QueryEditor.GetText(), "editing a recalled query starts browsing over")
+}
@@ -2,71 +2,316 @@ package tui
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/sqlgen"
 	"github.com/rivo/tview"
 )
 
+// focusedScope returns the key scope of the focused view.
+func (tui *TUI) focusedScope() KeyScope {
+	switch tui.App.GetFocus() {
+	case tui.Sources:
+		return ScopeSources
+	case tui.Schemas:
+		return ScopeSchemas
+	case tui.Tables:
+		return ScopeTables
+	case tui.Snippets:
+		return ScopeSnippets
+	case tui.PreviewTable:
+		return ScopePreview
+	case tui.QueryEditor:
+		return ScopeQuery
+	default:
+		return ScopeGlobal
+	}
+}
+
+// typing reports whether the focused view consumes printable keys as text input.
+func (tui *TUI) typing() bool {
+	switch tui.App.GetFocus().(type) {
+	case *tview.InputField, *Editor:
+		return true
+	default:
+		return false
+	}
+}
+
+// captureCtrlC keeps tview from stopping the application on Ctrl-C, which would skip the confirmation of open
+// transactions. Ctrl-C quits through quit while bound to it, even with an overlay open. Otherwise it goes on as a copy
+// of the event, which tview hands down like any other key, as it only stops on the original one.
+func (tui *TUI) captureCtrlC(event *tcell.EventKey) *tcell.EventKey {
+	if op, ok := tui.keys.Match(ScopeGlobal, event); ok && op == KeyQuitOp {
+		tui.quit()
+		return nil
+	}
+
+	return tcell.NewEventKey(event.Key(), event.Rune(), event.Modifiers())
+}
+
 func (tui *TUI) setupKeyboard() {
 	focusMapping := map[tview.Primitive]struct{ next, prev tview.Primitive }{
-		tui.Sources:      {tui.Schemas, tui.QueryInput},
+		tui.Sources:      {tui.Schemas, tui.QueryEditor},
 		tui.Schemas:      {tui.Tables, tui.Sources},
-		tui.Tables:       {tui.PreviewTable, tui.Schemas},
-		tui.PreviewTable: {tui.QueryInput, tui.Tables},
-		tui.QueryInput:   {tui.Sources, tui.PreviewTable},
+		tui.Tables:       {tui.Snippets, tui.Schemas},
+		tui.Snippets:     {tui.PreviewTable, tui.Tables},
+		tui.PreviewTable: {tui.QueryEditor, tui.Snippets},
+		tui.QueryEditor:  {tui.Sources, tui.PreviewTable},
 	}
 
 	// Setup app level keyboard shortcuts.
 	tui.App.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case KeyMapping[KeySourcesOp]:
-			tui.App.SetFocus(tui.Sources)
-		case KeyMapping[KeySchemasOp]:
-			tui.setFocus(tui.Schemas)
-		case KeyMapping[KeyTablesOp]:
-			tui.setFocus(tui.Tables)
-		case KeyMapping[KeyPreviewOp]:
-			tui.setFocus(tui.PreviewTable)
-		case KeyMapping[KeyQueryOp]:
-			tui.setFocus(tui.QueryInput)
-		case tcell.KeyCtrlR:
-			tui.LoadData()
-		case tcell.KeyCtrlF:
-			tui.toggleFocusMode()
-		case tcell.KeyEscape:
-			if tui.focusMode {
-				tui.toggleFocusMode()
+		if event.Key() == tcell.KeyCtrlC {
+			if event = tui.captureCtrlC(event); event == nil {
+				return nil
+			}
+		}
+		// Overlays handle their keys themselves.
+		if tui.overlayOpen() {
+			return event
+		}
+		// An open completion popup takes the keys it navigates with.
+		if tui.App.GetFocus() == tui.QueryEditor && tui.QueryEditor.completionKey(event) {
+			return event
+		}
+		// Bindings of the focused view shadow the global ones.
+		if scope := tui.focusedScope(); scope != ScopeGlobal {
+			if _, ok := tui.keys.Match(scope, event); ok {
+				return event
 			}
+		}
+		// Printable keys belong to the text being typed.
+		if event.Key() == tcell.KeyRune && tui.typing() {
+			return event
+		}
+
+		op, ok := tui.keys.Match(ScopeGlobal, event)
+		if !ok {
+			return event
+		}
 
-		/* Configuration for Tab & Backtab keys */
+		switch op {
+		case KeyExitFocusModeOp:
+			if !tui.focusMode {
+				return event
+			}
+			tui.toggleFocusMode()
 
-		// On Tab press set focus to the next element.
-		case tcell.KeyTab:
+		// On next/prev set focus to the next/prev element,
+		// and return `nil` to avoid the default Tab & Backtab behaviour of the primitive.
+		case KeyNextOp:
 			if focusMap, ok := focusMapping[tui.App.GetFocus()]; ok {
 				tui.setFocus(focusMap.next)
 			}
-
-			// Return `nil` to avoid default Backtab behaviour for the primitive.
-			return nil
-
-		// On Backtab press set focus to the prev element.
-		case tcell.KeyBacktab:
+		case KeyPrevOp:
 			if focusMap, ok := focusMapping[tui.App.GetFocus()]; ok {
 				tui.setFocus(focusMap.prev)
 			}
-
-			// Return `nil` to avoid default Backtab behaviour for the primitive.
-			return nil
+		default:
+			tui.runAction(op)
 		}
-		return event
+
+		return nil
 	})
 
 	// Setup Tables element level keyboard shortcuts.
 	tui.Tables.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Rune() {
-		case 'e':
-			tui.describeSelectedTable()
-		case 'p':
-			tui.previewSelectedTable()
+		op, ok := tui.keys.Match(ScopeTables, event)
+		if !ok {
+			return event
+		}
+
+		tui.runAction(op)
+		return nil
+	})
+
+	// Setup Preview element level keyboard shortcuts.
+	tui.PreviewTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		op, ok := tui.keys.Match(ScopePreview, event)
+		if !ok {
+			return event
+		}
+
+		tui.runAction(op)
+		return nil
+	})
+
+	// Setup Snippets element level keyboard shortcuts.
+	tui.Snippets.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		op, ok := tui.keys.Match(ScopeSnippets, event)
+		if !ok {
+			return event
+		}
+
+		tui.runAction(op)
+		return nil
+	})
+
+	// Setup Query editor level keyboard shortcuts.
+	tui.QueryEditor.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if tui.QueryEditor.completionKey(event) {
+			return event
+		}
+		op, ok := tui.keys.Match(ScopeQuery, event)
+		if !ok {
+			return event
+		}
+		if op != KeyCompleteOp {
+			tui.QueryEditor.closeCompletion()
+		}
+
+		switch op {
+		case KeyUndoOp:
+			tui.QueryEditor.Undo()
+		case KeyRedoOp:
+			tui.QueryEditor.Redo()
+		case KeyIndentOp:
+			tui.QueryEditor.Indent()
+		case KeyOutdentOp:
+			tui.QueryEditor.Outdent()
+		case KeyCompleteOp:
+			tui.QueryEditor.Complete()
+		case KeyHistoryPrevOp, KeyHistoryNextOp:
+			step := 1
+			if op == KeyHistoryNextOp {
+				step = -1
+			}
+			if event.Modifiers()&tcell.ModShift != 0 || !tui.recallHistory(step) {
+				return event
+			}
+		default:
+			tui.runAction(op)
 		}
-		return event
+		return nil
 	})
 }
+
+// contextualOps are the operations acting on the key event or on the text of the focused view. Only their key
+// handlers run them; runAction ignores them.
+var contextualOps = map[KeyOp]bool{
+	KeyNextOp:          true,
+	KeyPrevOp:          true,
+	KeyExitFocusModeOp: true,
+	KeyUndoOp:          true,
+	KeyRedoOp:          true,
+	KeyIndentOp:        true,
+	KeyOutdentOp:       true,
+	KeyCompleteOp:      true,
+	KeyHistoryPrevOp:   true,
+	KeyHistoryNextOp:   true,
+}
+
+// runAction runs the operation, whether its key was pressed or it was called from the command line.
+func (tui *TUI) runAction(op KeyOp) {
+	switch op {
+	case KeySourcesOp:
+		tui.setFocus(tui.Sources)
+	case KeySchemasOp:
+		tui.setFocus(tui.Schemas)
+	case KeyTablesOp:
+		tui.setFocus(tui.Tables)
+	case KeySnippetsOp:
+		tui.setFocus(tui.Snippets)
+	case KeyPreviewOp:
+		tui.setFocus(tui.PreviewTable)
+	case KeyQueryOp:
+		tui.setFocus(tui.QueryEditor)
+	case KeyReloadOp:
+		tui.LoadData()
+	case KeyFocusModeOp:
+		tui.toggleFocusMode()
+	case KeyFindOp:
+		tui.showFinder()
+	case KeyCommandOp:
+		tui.showCommandLine()
+	case KeyHelpOp:
+		tui.showHelp()
+	case KeyMessagesOp:
+		tui.showMessages()
+	case KeyQuitOp:
+		tui.quit()
+	case KeyDescribeTableOp:
+		tui.describeSelectedTable()
+	case KeyPreviewTableOp:
+		tui.previewSelectedTable()
+	case KeyEditSnippetOp:
+		tui.editSelectedSnippet()
+	case KeyOpenTabOp:
+		tui.openTab()
+	case KeyCloseTabOp:
+		tui.closeTab()
+	case KeyRenameTabOp:
+		tui.renameTab()
+	case KeyNextTabOp:
+		tui.cycleTab(1)
+	case KeyPrevTabOp:
+		tui.cycleTab(-1)
+	case KeyCopyCellOp:
+		tui.copyCell()
+	case KeyCopyRowOp:
+		tui.copyRows(false)
+	case KeyCopyAllOp:
+		tui.copyRows(true)
+	case KeyCopyFormatOp:
+		tui.cycleCopyFormat()
+	case KeyCellDetailOp:
+		tui.showCellDetail()
+	case KeyRecordOp:
+		tui.showRecord()
+	case KeyEditCellOp:
+		tui.editCell()
+	case KeyMarkRowOp:
+		tui.toggleMark()
+	case KeyInsertRowOp:
+		tui.insertRow()
+	case KeyDuplicateRowOp:
+		tui.duplicateRow()
+	case KeyDeleteRowsOp:
+		tui.deleteRows()
+	case KeyReviewChangesOp:
+		tui.reviewChanges()
+	case KeyDiscardChangesOp:
+		tui.discardChanges()
+	case KeyFilterOp:
+		tui.showFilterBar()
+	case KeyFilterEqualOp:
+		tui.quickFilter(sqlgen.Equal)
+	case KeyFilterNotEqualOp:
+		tui.quickFilter(sqlgen.NotEqual)
+	case KeyFilterLikeOp:
+		tui.quickFilter(sqlgen.Like)
+	case KeySortOp:
+		tui.toggleSort()
+	case KeyNextPageOp:
+		tui.nextPage()
+	case KeyPrevPageOp:
+		tui.prevPage()
+	case KeyJumpPageOp:
+		tui.jumpPage()
+	case KeyHideColumnOp:
+		tui.hideColumn()
+	case KeyChooseColumnsOp:
+		tui.chooseColumns()
+	case KeyMoveColumnLeftOp:
+		tui.moveColumn(-1)
+	case KeyMoveColumnRightOp:
+		tui.moveColumn(1)
+	case KeyFreezeColumnsOp:
+		tui.freezeColumns()
+	case KeyColumnWidthOp:
+		tui.cycleColumnWidth()
+	case KeyJumpColumnOp:
+		tui.jumpColumn()
+	case KeyExecuteOp:
+		tui.executeQuery(false)
+	case KeyExecuteAllOp:
+		tui.executeQuery(true)
+	case KeyHistoryOp:
+		tui.showHistory()
+	case KeyBeginOp:
+		tui.beginTransaction()
+	case KeyCommitOp:
+		tui.commitTransaction()
+	case KeyRollbackOp:
+		tui.rollbackTransaction()
+	}
+}
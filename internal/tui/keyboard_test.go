@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTUI_CaptureCtrlC(t *testing.T) {
+	ds := &txDataSource{open: true}
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.Pages = tview.NewPages().AddPage(mainPage, tview.NewBox(), true, true)
+	tui.transactions = map[string]internal.DataSource{"pg": ds}
+	tui.showOverlay(helpPage, tview.NewBox(), 10, 10)
+
+	ctrlC := tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModCtrl)
+	assert.Nil(t, tui.captureCtrlC(ctrlC))
+	front, _ := tui.Pages.GetFrontPage()
+	assert.Equal(t, confirmPage, front, "quitting over an overlay asks to roll back the open transaction")
+	assert.True(t, ds.open)
+
+	tui.hideOverlay(confirmPage)
+	keys, err := NewKeyMap(map[string]string{"quit": "Ctrl-X"})
+	require.NoError(t, err)
+	tui.keys = keys
+	event := tui.captureCtrlC(ctrlC)
+	if assert.NotNil(t, event) {
+		assert.NotSame(t, ctrlC, event, "tview stops the application on the original event")
+		assert.Equal(t, tcell.KeyCtrlC, event.Key())
+	}
+	front, _ = tui.Pages.GetFrontPage()
+	assert.Equal(t, helpPage, front, "Ctrl-C no longer quits once quit is bound to another key")
+}
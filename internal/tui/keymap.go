@@ -0,0 +1,472 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type (
+	// KeyOp defines dbui specific hotkey operations.
+	KeyOp int16
+
+	// KeyScope defines where a key binding is active: globally or only when a given panel has the focus.
+	// Panel bindings take precedence over global ones while the panel is focused.
+	KeyScope string
+
+	// Key is a single key stroke, either a named key or a rune, with optional modifiers.
+	Key struct {
+		Key  tcell.Key
+		Rune rune
+		Mod  tcell.ModMask
+	}
+
+	// KeyMap holds the effective key bindings: the defaults overridden by the user configuration.
+	KeyMap struct {
+		bindings map[KeyOp][]Key
+	}
+
+	keyAction struct {
+		op       KeyOp
+		name     string
+		scope    KeyScope
+		defaults string
+		help     string
+	}
+)
+
+const (
+	// KeySourcesOp is the operation corresponding to the activation of the Sources view.
+	KeySourcesOp KeyOp = iota
+	// KeySchemasOp is the operation corresponding to the activation of the Schemas view.
+	KeySchemasOp
+	// KeyTablesOp is the operation corresponding to the activation of the Tables view.
+	KeyTablesOp
+	// KeyPreviewOp is the operation corresponding to the activation of the Preview view.
+	KeyPreviewOp
+	// KeyQueryOp is the operation corresponding to the activation of the Query view.
+	KeyQueryOp
+	// KeyNextOp moves the focus to the next view.
+	KeyNextOp
+	// KeyPrevOp moves the focus to the previous view.
+	KeyPrevOp
+	// KeyReloadOp reloads schemas and tables of the current data source.
+	KeyReloadOp
+	// KeyFocusModeOp toggles the focus mode, which hides the navigation column.
+	KeyFocusModeOp
+	// KeyExitFocusModeOp leaves the focus mode.
+	KeyExitFocusModeOp
+	// KeyQuitOp exits the application.
+	KeyQuitOp
+	// KeyDescribeTableOp describes the table selected in the Tables view.
+	KeyDescribeTableOp
+	// KeyPreviewTableOp previews the table selected in the Tables view without moving the focus.
+	KeyPreviewTableOp
+	// KeyExecuteOp runs the selected text or the statement under the cursor of the Query editor.
+	KeyExecuteOp
+	// KeyExecuteAllOp runs every statement of the Query editor.
+	KeyExecuteAllOp
+	// KeyUndoOp reverts the last change in the Query editor.
+	KeyUndoOp
+	// KeyRedoOp reapplies the last reverted change in the Query editor.
+	KeyRedoOp
+	// KeyIndentOp indents the selected lines of the Query editor.
+	KeyIndentOp
+	// KeyOutdentOp outdents the selected lines of the Query editor.
+	KeyOutdentOp
+	// KeyCompleteOp opens the completion popup of the Query editor.
+	KeyCompleteOp
+	// KeyHistoryPrevOp replaces the Query editor text with the previous query of the history.
+	KeyHistoryPrevOp
+	// KeyHistoryNextOp replaces the Query editor text with the next query of the history.
+	KeyHistoryNextOp
+	// KeyHistoryOp opens the query history search.
+	KeyHistoryOp
+	// KeySnippetsOp is the operation corresponding to the activation of the Snippets view.
+	KeySnippetsOp
+	// KeyEditSnippetOp copies the snippet selected in the Snippets view into the Query editor.
+	KeyEditSnippetOp
+	// KeyOpenTabOp opens a new result tab in the Preview view.
+	KeyOpenTabOp
+	// KeyCloseTabOp closes the active result tab.
+	KeyCloseTabOp
+	// KeyRenameTabOp renames the active result tab.
+	KeyRenameTabOp
+	// KeyNextTabOp shows the next result tab.
+	KeyNextTabOp
+	// KeyPrevTabOp shows the previous result tab.
+	KeyPrevTabOp
+	// KeyCopyCellOp copies the value of the selected cell of the Preview view.
+	KeyCopyCellOp
+	// KeyCopyRowOp copies the selected row of the Preview view in the copy format.
+	KeyCopyRowOp
+	// KeyCopyAllOp copies every row of the Preview view in the copy format.
+	KeyCopyAllOp
+	// KeyCopyFormatOp switches to the next copy format.
+	KeyCopyFormatOp
+	// KeyCellDetailOp shows the full value of the selected cell of the Preview view.
+	KeyCellDetailOp
+	// KeyRecordOp shows the selected row of the Preview view as a list of column names and values.
+	KeyRecordOp
+	// KeyEditCellOp edits the selected cell of a table preview.
+	KeyEditCellOp
+	// KeyMarkRowOp marks or unmarks the selected row of the Preview view.
+	KeyMarkRowOp
+	// KeyInsertRowOp adds a row to the previewed table.
+	KeyInsertRowOp
+	// KeyDuplicateRowOp adds a copy of the selected row to the previewed table.
+	KeyDuplicateRowOp
+	// KeyDeleteRowsOp flags the marked rows, or the selected one, for deletion from the previewed table.
+	KeyDeleteRowsOp
+	// KeyReviewChangesOp shows the pending changes of the previewed table before applying them.
+	KeyReviewChangesOp
+	// KeyDiscardChangesOp drops the pending changes of the previewed table.
+	KeyDiscardChangesOp
+	// KeyFilterOp edits the WHERE clause filtering the previewed table.
+	KeyFilterOp
+	// KeyFilterEqualOp keeps the previewed rows whose value in the selected column equals the selected value.
+	KeyFilterEqualOp
+	// KeyFilterNotEqualOp keeps the previewed rows whose value in the selected column differs from the selected value.
+	KeyFilterNotEqualOp
+	// KeyFilterLikeOp keeps the previewed rows whose value in the selected column contains the selected value.
+	KeyFilterLikeOp
+	// KeySortOp sorts the previewed table by the selected column, ascending, descending or not at all.
+	KeySortOp
+	// KeyNextPageOp shows the next page of the previewed table.
+	KeyNextPageOp
+	// KeyPrevPageOp shows the previous page of the previewed table.
+	KeyPrevPageOp
+	// KeyJumpPageOp asks for a page of the previewed table to show.
+	KeyJumpPageOp
+	// KeyHideColumnOp hides the selected column of the Preview view.
+	KeyHideColumnOp
+	// KeyChooseColumnsOp lists the columns of the Preview view to hide or show them.
+	KeyChooseColumnsOp
+	// KeyMoveColumnLeftOp moves the selected column of the Preview view one place to the left.
+	KeyMoveColumnLeftOp
+	// KeyMoveColumnRightOp moves the selected column of the Preview view one place to the right.
+	KeyMoveColumnRightOp
+	// KeyFreezeColumnsOp keeps the columns up to the selected one in view while scrolling sideways.
+	KeyFreezeColumnsOp
+	// KeyColumnWidthOp switches to the next maximum column width of the Preview view.
+	KeyColumnWidthOp
+	// KeyJumpColumnOp searches a column of the Preview view by name and selects it.
+	KeyJumpColumnOp
+	// KeyFindOp opens the search over the tables, views and columns of the current data source.
+	KeyFindOp
+	// KeyCommandOp opens the command line.
+	KeyCommandOp
+	// KeyHelpOp shows the key bindings of the focused view and the global ones.
+	KeyHelpOp
+	// KeyMessagesOp shows or hides the message log.
+	KeyMessagesOp
+	// KeyBeginOp opens a transaction on the current data source.
+	KeyBeginOp
+	// KeyCommitOp commits the transaction of the current data source.
+	KeyCommitOp
+	// KeyRollbackOp rolls the transaction of the current data source back.
+	KeyRollbackOp
+)
+
+const (
+	// ScopeGlobal bindings are active regardless of the focused view.
+	ScopeGlobal KeyScope = "global"
+	// ScopeSources bindings are active in the Sources view.
+	ScopeSources KeyScope = "sources"
+	// ScopeSchemas bindings are active in the Schemas view.
+	ScopeSchemas KeyScope = "schemas"
+	// ScopeTables bindings are active in the Tables view.
+	ScopeTables KeyScope = "tables"
+	// ScopeSnippets bindings are active in the Snippets view.
+	ScopeSnippets KeyScope = "snippets"
+	// ScopePreview bindings are active in the Preview view.
+	ScopePreview KeyScope = "preview"
+	// ScopeQuery bindings are active in the Query view.
+	ScopeQuery KeyScope = "query"
+)
+
+// keyActions lists every remappable operation, in the order help texts present them.
+// The name is the key used in the `keys:` section of the configuration file.
+var keyActions = []keyAction{
+	{KeySourcesOp, "sources", ScopeGlobal, "Ctrl-A", "Focus sources"},
+	{KeySchemasOp, "schemas", ScopeGlobal, "Ctrl-S", "Focus schemas"},
+	{KeyTablesOp, "tables", ScopeGlobal, "Ctrl-D", "Focus tables"},
+	{KeyPreviewOp, "preview", ScopeGlobal, "Ctrl-E", "Focus preview"},
+	{KeyQueryOp, "query", ScopeGlobal, "Ctrl-Q", "Focus query"},
+	{KeySnippetsOp, "snippets", ScopeGlobal, "Ctrl-N", "Focus snippets"},
+	{KeyNextOp, "next", ScopeGlobal, "Tab", "Navigate"},
+	{KeyPrevOp, "prev", ScopeGlobal, "Backtab", "Navigate back"},
+	{KeyReloadOp, "reload", ScopeGlobal, "Ctrl-R", "Reload"},
+	{KeyFocusModeOp, "focusMode", ScopeGlobal, "Ctrl-F", "Focus"},
+	{KeyFindOp, "find", ScopeGlobal, "Ctrl-P", "Find"},
+	{KeyCommandOp, "command", ScopeGlobal, ":", "Command"},
+	{KeyHelpOp, "help", ScopeGlobal, "? F1", "Help"},
+	{KeyMessagesOp, "messages", ScopeGlobal, "Ctrl-L", "Messages"},
+	{KeyExitFocusModeOp, "exitFocusMode", ScopeGlobal, "Esc", "Leave focus mode"},
+	{KeyQuitOp, "quit", ScopeGlobal, "Ctrl-C", "Exit"},
+	{KeyDescribeTableOp, "describeTable", ScopeTables, "e", "Describe"},
+	{KeyPreviewTableOp, "previewTable", ScopeTables, "p", "Preview"},
+	{KeyEditSnippetOp, "editSnippet", ScopeSnippets, "e", "Edit"},
+	{KeyOpenTabOp, "openTab", ScopePreview, "o", "New tab"},
+	{KeyCloseTabOp, "closeTab", ScopePreview, "w", "Close tab"},
+	{KeyRenameTabOp, "renameTab", ScopePreview, "n", "Rename tab"},
+	{KeyNextTabOp, "nextTab", ScopePreview, "]", "Next tab"},
+	{KeyPrevTabOp, "prevTab", ScopePreview, "[", "Previous tab"},
+	{KeyCopyCellOp, "copyCell", ScopePreview, "c", "Copy cell"},
+	{KeyCopyRowOp, "copyRow", ScopePreview, "y", "Copy row"},
+	{KeyCopyAllOp, "copyAll", ScopePreview, "Y", "Copy all rows"},
+	{KeyCopyFormatOp, "copyFormat", ScopePreview, "F", "Copy format"},
+	{KeyCellDetailOp, "cellDetail", ScopePreview, "Enter v", "Show cell"},
+	{KeyRecordOp, "record", ScopePreview, "x", "Show record"},
+	{KeyEditCellOp, "editCell", ScopePreview, "i", "Edit cell"},
+	{KeyMarkRowOp, "markRow", ScopePreview, "Space", "Mark row"},
+	{KeyInsertRowOp, "insertRow", ScopePreview, "a", "Insert row"},
+	{KeyDuplicateRowOp, "duplicateRow", ScopePreview, "D", "Duplicate row"},
+	{KeyDeleteRowsOp, "deleteRows", ScopePreview, "d", "Delete rows"},
+	{KeyReviewChangesOp, "reviewChanges", ScopePreview, "S", "Review and apply changes"},
+	{KeyDiscardChangesOp, "discardChanges", ScopePreview, "U", "Discard changes"},
+	{KeyFilterOp, "filter", ScopePreview, "/", "Filter"},
+	{KeyFilterEqualOp, "filterEqual", ScopePreview, "=", "Filter by value"},
+	{KeyFilterNotEqualOp, "filterNotEqual", ScopePreview, "!", "Filter out value"},
+	{KeyFilterLikeOp, "filterLike", ScopePreview, "~", "Filter by substring"},
+	{KeySortOp, "sort", ScopePreview, "s", "Sort"},
+	{KeyNextPageOp, "nextPage", ScopePreview, ">", "Next page"},
+	{KeyPrevPageOp, "prevPage", ScopePreview, "<", "Previous page"},
+	{KeyJumpPageOp, "jumpPage", ScopePreview, "P", "Go to page"},
+	{KeyHideColumnOp, "hideColumn", ScopePreview, "-", "Hide column"},
+	{KeyChooseColumnsOp, "chooseColumns", ScopePreview, "C", "Choose columns"},
+	{KeyMoveColumnLeftOp, "moveColumnLeft", ScopePreview, "H", "Move column left"},
+	{KeyMoveColumnRightOp, "moveColumnRight", ScopePreview, "L", "Move column right"},
+	{KeyFreezeColumnsOp, "freezeColumns", ScopePreview, "f", "Freeze columns"},
+	{KeyColumnWidthOp, "columnWidth", ScopePreview, "W", "Column width"},
+	{KeyJumpColumnOp, "jumpColumn", ScopePreview, "@", "Go to column"},
+	{KeyExecuteOp, "execute", ScopeQuery, "Ctrl-Enter Ctrl-J", "Run statement"},
+	{KeyExecuteAllOp, "executeAll", ScopeQuery, "Alt-Enter", "Run all"},
+	{KeyUndoOp, "undo", ScopeQuery, "Ctrl-Z", "Undo"},
+	{KeyRedoOp, "redo", ScopeQuery, "Ctrl-Y", "Redo"},
+	{KeyIndentOp, "indent", ScopeQuery, "Tab", "Indent"},
+	{KeyOutdentOp, "outdent", ScopeQuery, "Backtab", "Outdent"},
+	{KeyCompleteOp, "complete", ScopeQuery, "Ctrl-Space", "Complete"},
+	{KeyHistoryPrevOp, "historyPrev", ScopeQuery, "Up", "Previous query"},
+	{KeyHistoryNextOp, "historyNext", ScopeQuery, "Down", "Next query"},
+	{KeyHistoryOp, "history", ScopeQuery, "Ctrl-R", "History"},
+	{KeyBeginOp, "begin", ScopeQuery, "Alt-b", "Begin transaction"},
+	{KeyCommitOp, "commit", ScopeQuery, "Alt-c", "Commit transaction"},
+	{KeyRollbackOp, "rollback", ScopeQuery, "Alt-r", "Roll back transaction"},
+}
+
+// keyNames maps the names accepted in the configuration to tcell keys.
+var keyNames = func() map[string]tcell.Key {
+	names := map[string]tcell.Key{"Shift-Tab": tcell.KeyBacktab, "Escape": tcell.KeyEsc}
+	for k, name := range tcell.KeyNames {
+		names[name] = k
+	}
+	return names
+}()
+
+// ParseKey parses a key stroke like "Ctrl-R", "Alt-x", "Ctrl-Enter", "F5", "Space", or "e".
+func ParseKey(s string) (Key, error) {
+	if k, ok := keyNames[s]; ok {
+		return Key{Key: k}, nil
+	}
+
+	var mod tcell.ModMask
+	rest := s
+	for {
+		switch {
+		case strings.HasPrefix(rest, "Ctrl-") && len(rest) > len("Ctrl-"):
+			mod |= tcell.ModCtrl
+			rest = rest[len("Ctrl-"):]
+			continue
+		case strings.HasPrefix(rest, "Alt-") && len(rest) > len("Alt-"):
+			mod |= tcell.ModAlt
+			rest = rest[len("Alt-"):]
+			continue
+		case strings.HasPrefix(rest, "Shift-") && len(rest) > len("Shift-"):
+			mod |= tcell.ModShift
+			rest = rest[len("Shift-"):]
+			continue
+		}
+		break
+	}
+
+	if k, ok := keyNames[rest]; ok {
+		return Key{Key: k, Mod: mod}, nil
+	}
+	if rest == "Space" {
+		rest = " "
+	}
+	if utf8.RuneCountInString(rest) == 1 && mod&^tcell.ModAlt == 0 {
+		r, _ := utf8.DecodeRuneInString(rest)
+		return Key{Key: tcell.KeyRune, Rune: r, Mod: mod}, nil
+	}
+
+	return Key{}, fmt.Errorf("unknown key %q", s)
+}
+
+// parseKeys parses a whitespace separated list of key strokes.
+func parseKeys(s string) ([]Key, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, errors.New("no key given")
+	}
+
+	keys := make([]Key, 0, len(fields))
+	for _, f := range fields {
+		k, err := ParseKey(f)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+// String returns the key stroke in the format ParseKey accepts.
+func (k Key) String() string {
+	var b strings.Builder
+	if k.Mod&tcell.ModCtrl != 0 {
+		b.WriteString("Ctrl-")
+	}
+	if k.Mod&tcell.ModAlt != 0 {
+		b.WriteString("Alt-")
+	}
+	if k.Mod&tcell.ModShift != 0 {
+		b.WriteString("Shift-")
+	}
+
+	switch {
+	case k.Key == tcell.KeyRune && k.Rune == ' ':
+		b.WriteString("Space")
+	case k.Key == tcell.KeyRune:
+		b.WriteRune(k.Rune)
+	case k.Key == tcell.KeyBacktab:
+		b.WriteString("Shift-Tab")
+	default:
+		b.WriteString(tcell.KeyNames[k.Key])
+	}
+
+	return b.String()
+}
+
+// Matches reports whether the event was produced by this key stroke.
+func (k Key) Matches(event *tcell.EventKey) bool {
+	if event.Key() != k.Key {
+		return false
+	}
+	if k.Key == tcell.KeyRune {
+		return event.Rune() == k.Rune && event.Modifiers()&tcell.ModAlt == k.Mod&tcell.ModAlt
+	}
+	// Control characters and Backtab carry their modifiers implicitly.
+	if strings.HasPrefix(tcell.KeyNames[k.Key], "Ctrl-") || k.Key == tcell.KeyBacktab {
+		return true
+	}
+
+	return event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt) == k.Mod&(tcell.ModCtrl|tcell.ModAlt)
+}
+
+func findKeyAction(name string) (keyAction, bool) {
+	for _, a := range keyActions {
+		if a.name == name {
+			return a, true
+		}
+	}
+
+	return keyAction{}, false
+}
+
+// ValidateKeyBinding checks a single entry of the `keys:` configuration section.
+func ValidateKeyBinding(action, keys string) error {
+	if _, ok := findKeyAction(action); !ok {
+		return fmt.Errorf("unknown action %q", action)
+	}
+	_, err := parseKeys(keys)
+
+	return err
+}
+
+// NewKeyMap returns the default key bindings overridden by the provided action to keys mapping.
+// Keys of an action are separated by whitespace. Two actions bound to the same key in the same scope are reported as a conflict.
+func NewKeyMap(overrides map[string]string) (*KeyMap, error) {
+	km := &KeyMap{bindings: map[KeyOp][]Key{}}
+	for _, a := range keyActions {
+		keys, err := parseKeys(a.defaults)
+		if err != nil {
+			panic(fmt.Sprintf("invalid default binding for %s: %v", a.name, err))
+		}
+		km.bindings[a.op] = keys
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		a, ok := findKeyAction(name)
+		if !ok {
+			return nil, fmt.Errorf("keys: unknown action %q", name)
+		}
+		keys, err := parseKeys(overrides[name])
+		if err != nil {
+			return nil, fmt.Errorf("keys: %s: %w", name, err)
+		}
+		km.bindings[a.op] = keys
+	}
+
+	return km, km.conflicts()
+}
+
+func (km *KeyMap) conflicts() error {
+	type slot struct {
+		scope KeyScope
+		key   Key
+	}
+
+	var errs []error
+	owners := map[slot]string{}
+	for _, a := range keyActions {
+		for _, k := range km.bindings[a.op] {
+			s := slot{a.scope, k}
+			if owner, ok := owners[s]; ok {
+				errs = append(errs, fmt.Errorf("keys: %s is bound to both %q and %q in %s scope", k, owner, a.name, a.scope))
+				continue
+			}
+			owners[s] = a.name
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Match returns the operation bound to the event in the given scope.
+func (km *KeyMap) Match(scope KeyScope, event *tcell.EventKey) (KeyOp, bool) {
+	for _, a := range keyActions {
+		if a.scope != scope {
+			continue
+		}
+		for _, k := range km.bindings[a.op] {
+			if k.Matches(event) {
+				return a.op, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// Label returns the keys bound to the operation in a human readable form, e.g. "Ctrl-Enter / Ctrl-J".
+func (km *KeyMap) Label(op KeyOp) string {
+	labels := make([]string, 0, len(km.bindings[op]))
+	for _, k := range km.bindings[op] {
+		labels = append(labels, k.String())
+	}
+
+	return strings.Join(labels, " / ")
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Key
+		wantErr bool
+	}{
+		{in: "Ctrl-R", want: Key{Key: tcell.KeyCtrlR}},
+		{in: "Tab", want: Key{Key: tcell.KeyTab}},
+		{in: "Shift-Tab", want: Key{Key: tcell.KeyBacktab}},
+		{in: "F5", want: Key{Key: tcell.KeyF5}},
+		{in: "Ctrl-Enter", want: Key{Key: tcell.KeyEnter, Mod: tcell.ModCtrl}},
+		{in: "Alt-Up", want: Key{Key: tcell.KeyUp, Mod: tcell.ModAlt}},
+		{in: "e", want: Key{Key: tcell.KeyRune, Rune: 'e'}},
+		{in: "?", want: Key{Key: tcell.KeyRune, Rune: '?'}},
+		{in: "-", want: Key{Key: tcell.KeyRune, Rune: '-'}},
+		{in: "Alt-x", want: Key{Key: tcell.KeyRune, Rune: 'x', Mod: tcell.ModAlt}},
+		{in: "Space", want: Key{Key: tcell.KeyRune, Rune: ' '}},
+		{in: "Ctrl-x", wantErr: true},
+		{in: "Hyper-Q", wantErr: true},
+		{in: "ee", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseKey(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			again, err := ParseKey(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, got, again, "String must round-trip through ParseKey")
+		})
+	}
+}
+
+func TestKey_Matches(t *testing.T) {
+	ctrlEnter, _ := ParseKey("Ctrl-Enter")
+	enter, _ := ParseKey("Enter")
+	ctrlR, _ := ParseKey("Ctrl-R")
+	altX, _ := ParseKey("Alt-x")
+	x, _ := ParseKey("x")
+
+	assert.True(t, ctrlEnter.Matches(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModCtrl)))
+	assert.False(t, ctrlEnter.Matches(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)))
+	assert.True(t, enter.Matches(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)))
+	assert.False(t, enter.Matches(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModCtrl)))
+	assert.True(t, ctrlR.Matches(tcell.NewEventKey(tcell.KeyCtrlR, 'r', tcell.ModCtrl)))
+	assert.True(t, altX.Matches(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModAlt)))
+	assert.False(t, altX.Matches(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)))
+	assert.True(t, x.Matches(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)))
+	assert.False(t, x.Matches(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone)))
+}
+
+func TestNewKeyMap(t *testing.T) {
+	km, err := NewKeyMap(nil)
+	require.NoError(t, err, "defaults must not conflict")
+	assert.Equal(t, "Ctrl-A", km.Label(KeySourcesOp))
+	assert.Equal(t, "e", km.Label(KeyDescribeTableOp))
+
+	km, err = NewKeyMap(map[string]string{"reload": "F5 Ctrl-R", "describeTable": "d"})
+	require.NoError(t, err)
+	assert.Equal(t, "F5 / Ctrl-R", km.Label(KeyReloadOp))
+
+	op, ok := km.Match(ScopeGlobal, tcell.NewEventKey(tcell.KeyF5, 0, tcell.ModNone))
+	assert.True(t, ok)
+	assert.Equal(t, KeyReloadOp, op)
+
+	op, ok = km.Match(ScopeTables, tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone))
+	assert.True(t, ok)
+	assert.Equal(t, KeyDescribeTableOp, op)
+
+	_, ok = km.Match(ScopeTables, tcell.NewEventKey(tcell.KeyRune, 'e', tcell.ModNone))
+	assert.False(t, ok, "the default binding is replaced, not extended")
+
+	_, ok = km.Match(ScopeGlobal, tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone))
+	assert.False(t, ok, "panel bindings are not global")
+}
+
+func TestNewKeyMap_Negative(t *testing.T) {
+	_, err := NewKeyMap(map[string]string{"teleport": "Ctrl-T"})
+	assert.EqualError(t, err, `keys: unknown action "teleport"`)
+
+	_, err = NewKeyMap(map[string]string{"reload": ""})
+	assert.EqualError(t, err, `keys: reload: no key given`)
+
+	_, err = NewKeyMap(map[string]string{"reload": "Ctrl-A"})
+	assert.EqualError(t, err, `keys: Ctrl-A is bound to both "sources" and "reload" in global scope`)
+
+	_, err = NewKeyMap(map[string]string{"previewTable": "e"})
+	assert.EqualError(t, err, `keys: e is bound to both "describeTable" and "previewTable" in tables scope`)
+
+	_, err = NewKeyMap(map[string]string{"describeTable": "Ctrl-R"})
+	assert.NoError(t, err, "panel bindings may shadow global ones")
+}
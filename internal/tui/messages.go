@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/eventlog"
+)
+
+const messagesPage = "messages"
+
+// flashDuration is how long infos and warnings stay in the footer.
+const flashDuration = 3 * time.Second
+
+// messageIndent is the width of the time and severity heading every message of the log, e.g. "15:04:05 warning ".
+const messageIndent = len("15:04:05 warning ")
+
+// notify logs the message and shows it in the footer. Infos and warnings give way after a while, errors stay until
+// the message log is read.
+func (tui *TUI) notify(severity eventlog.Severity, text string) {
+	entry := tui.messages.Add(severity, text)
+	tui.queueUpdateDraw(func() {
+		tui.flash = nil
+		if severity != eventlog.Error {
+			tui.flash = &entry
+		}
+		if tui.messageViewer != nil {
+			tui.messages.Dismiss()
+			tui.writeMessages(tui.messageViewer, tui.messages.Entries())
+		}
+		tui.renderFooter()
+	})
+	if severity == eventlog.Error {
+		return
+	}
+
+	time.AfterFunc(flashDuration, func() {
+		tui.queueUpdateDraw(func() {
+			if tui.flash == &entry {
+				tui.flash = nil
+				tui.renderFooter()
+			}
+		})
+	})
+}
+
+// renderFooter shows the flashed message, else the last unread error, else the key help.
+func (tui *TUI) renderFooter() {
+	unread := tui.messages.Unread()
+	switch {
+	case tui.flash != nil:
+		style := tui.theme.Message
+		if tui.flash.Severity == eventlog.Warning {
+			style = tui.theme.Warning
+		}
+		tui.FooterText.SetText(tui.flash.Text).SetTextStyle(style)
+	case len(unread) > 0:
+		last, _, _ := strings.Cut(unread[len(unread)-1].Text, "\n")
+		count := "1 unread error"
+		if len(unread) > 1 {
+			count = fmt.Sprintf("%d unread errors", len(unread))
+		}
+		tui.FooterText.SetText(fmt.Sprintf("%s\n%s · Messages [ %s ]", last, count, tui.keys.Label(KeyMessagesOp))).
+			SetTextStyle(tui.theme.Error)
+	default:
+		tui.FooterText.SetText(tui.footer()).SetTextStyle(tui.theme.Footer)
+	}
+}
+
+// writeMessages writes the entries to the viewer, a time, severity and full text per message, and scrolls to the
+// last one.
+func (tui *TUI) writeMessages(viewer *Viewer, entries []eventlog.Entry) {
+	viewer.Clear()
+	if len(entries) == 0 {
+		viewer.Write("No messages yet", tui.theme.Secondary)
+		return
+	}
+
+	for i, e := range entries {
+		if i > 0 {
+			viewer.Write("\n", tui.theme.Text)
+		}
+		style := tui.theme.Message
+		switch e.Severity {
+		case eventlog.Warning:
+			style = tui.theme.Warning
+		case eventlog.Error:
+			style = tui.theme.Error
+		}
+		viewer.Write(e.Time.Format("15:04:05")+" ", tui.theme.Secondary)
+		viewer.Write(fmt.Sprintf("%-*s", messageIndent-len("15:04:05 "), e.Severity), style)
+		// Further lines of the text line up with its first one.
+		viewer.Write(strings.ReplaceAll(e.Text, "\n", "\n"+strings.Repeat(" ", messageIndent)), tui.theme.Text)
+	}
+	viewer.ScrollToEnd()
+}
+
+// showMessages opens the message log, newest message last, which dismisses the unread errors. The messages key
+// closes it again.
+func (tui *TUI) showMessages() {
+	viewer := NewViewer().SetHang(messageIndent)
+	tui.messages.Dismiss()
+	tui.writeMessages(viewer, tui.messages.Entries())
+	tui.messageViewer = viewer
+	tui.renderFooter()
+
+	viewer.SetBorder(true).SetTitle(fmt.Sprintf("Messages [ Esc / %s: close ]", tui.keys.Label(KeyMessagesOp))).
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+	viewer.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		op, ok := tui.keys.Match(ScopeGlobal, event)
+		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyRune && event.Rune() == 'q' || ok && op == KeyMessagesOp {
+			tui.messageViewer = nil
+			tui.hideOverlay(messagesPage)
+			return nil
+		}
+		return event
+	})
+
+	tui.showOverlay(messagesPage, viewer, 120, 40)
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kenanbek/dbui/internal/eventlog"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTUI_RenderFooter(t *testing.T) {
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.FooterText = tview.NewTextView()
+
+	tui.renderFooter()
+	assert.Equal(t, tui.footer(), tui.FooterText.GetText(false))
+
+	tui.showError(errors.New("connection refused\nis the server running?"))
+	tui.showError(errors.New("no such table: users"))
+	tui.renderFooter()
+	assert.Equal(t, "no such table: users\n2 unread errors · Messages [ Ctrl-L ]", tui.FooterText.GetText(false),
+		"errors stay until read")
+
+	flash := tui.messages.Add(eventlog.Info, "Copied 1 row")
+	tui.flash = &flash
+	tui.renderFooter()
+	assert.Equal(t, "Copied 1 row", tui.FooterText.GetText(false), "infos show over unread errors")
+
+	tui.flash = nil
+	tui.messages.Dismiss()
+	tui.renderFooter()
+	assert.Equal(t, tui.footer(), tui.FooterText.GetText(false))
+}
+
+func TestTUI_WriteMessages(t *testing.T) {
+	tui := newTestTUI(t)
+	at := time.Date(2024, 5, 1, 15, 4, 5, 0, time.Local)
+	v := NewViewer()
+	tui.writeMessages(v, []eventlog.Entry{
+		{Time: at, Severity: eventlog.Info, Text: "Copied 1 row"},
+		{Time: at, Severity: eventlog.Error, Text: "connection refused\nis the server running?"},
+	})
+
+	var lines []string
+	for _, l := range v.lines {
+		lines = append(lines, string(l.text))
+	}
+	assert.Equal(t, []string{
+		"15:04:05 info    Copied 1 row",
+		"15:04:05 error   connection refused",
+		"                 is the server running?",
+	}, lines)
+
+	tui.writeMessages(v, nil)
+	assert.Equal(t, "No messages yet", string(v.lines[0].text))
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"github.com/rivo/tview"
+)
+
+// mainPage is the page holding the main layout. Every other page is an overlay shown on top of it.
+const mainPage = "main"
+
+// overlayOpen reports whether an overlay is shown. Global key bindings are disabled meanwhile.
+func (tui *TUI) overlayOpen() bool {
+	name, _ := tui.Pages.GetFrontPage()
+	return name != mainPage
+}
+
+// showOverlay shows the primitive centered on top of the main layout and focuses it.
+// The focus returns to the previously focused view when the overlay is hidden.
+func (tui *TUI) showOverlay(name string, p tview.Primitive, width, height int) {
+	if !tui.overlayOpen() {
+		tui.overlayReturn = tui.App.GetFocus()
+	}
+
+	tui.Pages.AddPage(name, centered{p, width, height}, true, true)
+	tui.App.SetFocus(p)
+}
+
+// hideOverlay removes the overlay and gives the focus back.
+func (tui *TUI) hideOverlay(name string) {
+	tui.Pages.RemovePage(name)
+	if !tui.overlayOpen() && tui.overlayReturn != nil {
+		tui.App.SetFocus(tui.overlayReturn)
+		tui.overlayReturn = nil
+	}
+}
+
+// centered places a primitive in the middle of the area given to it, at most width by height cells large.
+// Unlike a layout of spacers, it leaves the area around the primitive untouched, so the main layout shows through.
+type centered struct {
+	tview.Primitive
+	width, height int
+}
+
+// SetRect centers the primitive in the given area.
+func (c centered) SetRect(x, y, width, height int) {
+	w, h := min(c.width, width), min(c.height, height)
+	c.Primitive.SetRect(x+(width-w)/2, y+(height-h)/2, w, h)
+}
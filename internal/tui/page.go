@@ -0,0 +1,200 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal"
+	"github.com/rivo/tview"
+)
+
+const pagePage = "page"
+
+// DefaultPageSize is the number of rows per preview page used when the configuration does not set one.
+const DefaultPageSize = 50
+
+// pageSize returns the number of rows per preview page, as last set on the command line or else configured.
+func (tui *TUI) pageSize() int {
+	if tui.limit > 0 {
+		return tui.limit
+	}
+	if n := tui.ac.PageSize(); n > 0 {
+		return n
+	}
+	return DefaultPageSize
+}
+
+// previewTable reads the first page of the table. Tables with a primary key are sorted by it, so that the next pages
+// are found by key instead of by offset.
+func (tui *TUI) previewTable(schema, table string) (resultTab, error) {
+	ds := tui.dc.Current()
+	preview := internal.Preview{Limit: tui.pageSize()}
+	if columns, err := ds.ListColumns(schema, table); err == nil {
+		for _, c := range columns {
+			if c.PrimaryKey {
+				preview.Key = append(preview.Key, c.Name)
+			}
+		}
+	}
+
+	data, err := ds.PreviewTable(schema, table, preview)
+	if err != nil {
+		return resultTab{}, err
+	}
+	// The estimate only serves the title, which does without it.
+	estimate, err := ds.EstimateRows(schema, table)
+	if err != nil {
+		estimate = -1
+	}
+
+	return resultTab{schema: schema, table: table, data: data, preview: preview, first: 1, estimate: estimate}, nil
+}
+
+// keyOf returns the primary key values of the row when the preview pages by key and the result has every key column.
+func keyOf(tab *resultTab, row int) ([]*string, bool) {
+	if len(tab.preview.OrderBy) > 0 || len(tab.preview.Key) == 0 {
+		return nil, false
+	}
+
+	values := make([]*string, len(tab.preview.Key))
+	for i, k := range tab.preview.Key {
+		column := slices.IndexFunc(tab.data[0], func(name *string) bool { return name != nil && *name == k })
+		if column < 0 {
+			return nil, false
+		}
+		values[i] = tab.data[row][column]
+	}
+
+	return values, true
+}
+
+// nextPage shows the rows after the ones of the preview.
+func (tui *TUI) nextPage() {
+	tab, err := tui.previewTab()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+	rows := len(tab.data) - 1
+	if rows < tab.preview.Limit {
+		tui.showWarning("this is the last page")
+		return
+	}
+
+	preview := tab.preview
+	preview.Before = nil
+	if key, ok := keyOf(tab, rows); ok {
+		preview.After, preview.Offset = key, 0
+	} else {
+		preview.Offset += rows
+	}
+	tui.showPage(tab, preview, tab.first+rows)
+}
+
+// prevPage shows the rows before the ones of the preview.
+func (tui *TUI) prevPage() {
+	tab, err := tui.previewTab()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+	if tab.first <= 1 {
+		tui.showWarning("this is the first page")
+		return
+	}
+
+	preview := tab.preview
+	preview.After = nil
+	if key, ok := keyOf(tab, 1); ok && len(tab.data) > 1 {
+		preview.Before, preview.Offset = key, 0
+	} else {
+		preview.Before, preview.Offset = nil, max(0, tab.first-1-preview.Limit)
+	}
+	tui.showPage(tab, preview, max(1, tab.first-preview.Limit))
+}
+
+// jumpPage asks for the number of the page to show. Jumping pages by offset, as there is no key to start from.
+func (tui *TUI) jumpPage() {
+	tab, err := tui.previewTab()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+
+	title := "Go to page"
+	if tab.estimate >= 0 && tab.preview.Where == "" {
+		title += fmt.Sprintf(" of ~%d", max(1, (tab.estimate+int64(tab.preview.Limit)-1)/int64(tab.preview.Limit)))
+	}
+	input := tview.NewInputField().SetText(strconv.Itoa((tab.first-1)/tab.preview.Limit + 1)).
+		SetAcceptanceFunc(tview.InputFieldInteger).SetFieldStyle(tui.theme.Input).
+		SetLabel("Page ").SetLabelStyle(tcell.StyleDefault.Foreground(tui.theme.Title))
+	input.SetDoneFunc(func(key tcell.Key) {
+		tui.hideOverlay(pagePage)
+		page, err := strconv.Atoi(input.GetText())
+		if key != tcell.KeyEnter || err != nil {
+			return
+		}
+
+		preview := tab.preview
+		preview.After, preview.Before = nil, nil
+		preview.Offset = (max(1, page) - 1) * preview.Limit
+		tui.showPage(tab, preview, preview.Offset+1)
+	})
+	input.SetBorder(true).SetTitle(title + " [ Enter: go · Esc: cancel ]").
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	tui.showOverlay(pagePage, input, 40, 3)
+}
+
+// showPage shows the page of the preview starting at the first row, counting from 1. An empty page is not shown,
+// as there are no rows after the last page.
+func (tui *TUI) showPage(tab *resultTab, preview internal.Preview, first int) {
+	data, err := tui.dc.Current().PreviewTable(tab.schema, tab.table, preview)
+	if err != nil {
+		tui.showError(err)
+		return
+	}
+	if len(data) <= 1 && first > 1 {
+		tui.showWarning("there are no more rows")
+		return
+	}
+	// Fewer rows before the key than a page holds means rows were deleted meanwhile: start over.
+	if preview.Before != nil && len(data)-1 < preview.Limit {
+		preview.Before = nil
+		tui.showPage(tab, preview, 1)
+		return
+	}
+
+	tui.saveTabState()
+	tab.data, tab.preview, tab.first, tab.marked = data, preview, first, nil
+	tab.row, tab.rowOffset = 1, 0
+	tui.renderTab()
+	// Without a key or a sort order the database may return the rows in a different order for every page.
+	if preview.Offset > 0 && len(preview.OrderBy) == 0 && len(preview.Key) == 0 {
+		tui.showWarning(fmt.Sprintf("%s has no primary key and the preview is not sorted, so pages may repeat or miss rows", tab.table))
+	}
+}
+
+// pageTitle describes the rows of the page for the preview title, e.g. " · rows 51–100 of ~1200". The estimate of the
+// table size is left out while the preview is filtered; the size of the last page tells the exact number.
+func pageTitle(tab *resultTab) string {
+	rows := len(tab.data) - 1
+	switch {
+	case tab.preview.Limit == 0:
+		return ""
+	case rows <= 0:
+		return " · no rows"
+	}
+
+	title := fmt.Sprintf(" · rows %d–%d", tab.first, tab.first+rows-1)
+	switch {
+	case rows < tab.preview.Limit:
+		title += fmt.Sprintf(" of %d", tab.first+rows-1)
+	case tab.estimate >= 0 && tab.preview.Where == "":
+		title += fmt.Sprintf(" of ~%d", max(tab.estimate, int64(tab.first+rows-1)))
+	}
+
+	return title
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/eventlog"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageTitle(t *testing.T) {
+	tab := &resultTab{data: testData(50), preview: internal.Preview{Limit: 50}, first: 51, estimate: 1200}
+	assert.Equal(t, " · rows 51–100 of ~1200", pageTitle(tab))
+
+	tab.estimate = -1
+	assert.Equal(t, " · rows 51–100", pageTitle(tab))
+
+	tab.estimate, tab.preview.Where = 1200, "a = 1"
+	assert.Equal(t, " · rows 51–100", pageTitle(tab), "the estimate is of the whole table")
+
+	tab.data = testData(7)
+	assert.Equal(t, " · rows 51–57 of 57", pageTitle(tab), "the last page tells the exact number")
+
+	tab.data = testData(0)
+	assert.Equal(t, " · no rows", pageTitle(tab))
+
+	tab.preview.Limit = 0
+	assert.Empty(t, pageTitle(tab), "query results are not paged")
+}
+
+func TestTUI_Paging(t *testing.T) {
+	data := [][]*string{{sptr("name"), sptr("id")}, {sptr("a"), sptr("1")}, {sptr("b"), sptr("2")}}
+	ds := &fakeDataSource{data: data}
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.dc = fakeController{alias: "pg", sources: [][]string{{"pg", "postgresql"}}, ds: ds}
+	preview := internal.Preview{Limit: 2, Key: []string{"id"}}
+	*tui.currentTab() = resultTab{label: "users", alias: "pg", table: "users", data: data, preview: preview, first: 1, row: 1}
+	tui.renderTab()
+
+	tui.nextPage()
+	assert.Equal(t, 3, tui.currentTab().first)
+	tui.prevPage()
+	assert.Equal(t, 1, tui.currentTab().first)
+
+	tui.currentTab().preview.OrderBy = []internal.Order{{Column: "name"}}
+	tui.nextPage()
+	tui.nextPage()
+	assert.Equal(t, 5, tui.currentTab().first)
+
+	keyed, sorted := preview, preview
+	sorted.OrderBy = []internal.Order{{Column: "name"}}
+	after, before, offset2, offset4 := keyed, keyed, sorted, sorted
+	after.After, before.Before, offset2.Offset, offset4.Offset = []*string{sptr("2")}, []*string{sptr("1")}, 2, 4
+	assert.Equal(t, []internal.Preview{after, before, offset2, offset4}, ds.previews,
+		"pages are found by key, or by offset when sorted by other columns")
+
+	ds.data = data[:2]
+	tui.applyPreview(tui.currentTab(), sorted)
+	ds.previews = nil
+	tui.nextPage()
+	assert.Empty(t, ds.previews, "a page with fewer rows than the limit is the last one")
+}
+
+func TestTUI_PagingWithoutKey(t *testing.T) {
+	data := [][]*string{{sptr("name")}, {sptr("a")}, {sptr("b")}}
+	ds := &fakeDataSource{data: data}
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.dc = fakeController{alias: "pg", sources: [][]string{{"pg", "postgresql"}}, ds: ds}
+	*tui.currentTab() = resultTab{label: "log", alias: "pg", table: "log", data: data, preview: internal.Preview{Limit: 2}, first: 1, row: 1}
+	tui.renderTab()
+
+	tui.nextPage()
+	assert.Equal(t, 3, tui.currentTab().first)
+	entries := tui.messages.Entries()
+	require.NotEmpty(t, entries)
+	assert.Equal(t, eventlog.Warning, entries[len(entries)-1].Severity, "pages of a table without a key are unreliable")
+
+	tui.currentTab().preview.OrderBy = []internal.Order{{Column: "name"}}
+	tui.nextPage()
+	assert.Len(t, tui.messages.Entries(), len(entries), "the sort order decides the pages")
+}
@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal"
+	"github.com/rivo/tview"
+)
+
+const rowFormPage = "row"
+
+// fieldMode tells what a field of the row form inserts.
+type fieldMode int
+
+const (
+	// valueMode inserts the text of the field, possibly an empty string.
+	valueMode fieldMode = iota
+	// defaultMode leaves the column out, so that it gets its default value.
+	defaultMode
+	// nullMode inserts NULL.
+	nullMode
+)
+
+// rowField is a field of the row form.
+type rowField struct {
+	column internal.Column
+	input  *tview.InputField
+	mode   fieldMode
+}
+
+// setMode switches the field to the mode, showing what it inserts while it is empty.
+func (f *rowField) setMode(mode fieldMode) {
+	if mode != valueMode {
+		f.input.SetText("")
+	}
+	f.mode = mode
+
+	switch mode {
+	case defaultMode:
+		placeholder := "DEFAULT"
+		if f.column.Default != nil {
+			placeholder += " " + *f.column.Default
+		}
+		f.input.SetPlaceholder(placeholder)
+	case nullMode:
+		f.input.SetPlaceholder("NULL")
+	default:
+		f.input.SetPlaceholder("empty string")
+	}
+}
+
+// cycleMode switches the field to the next mode: a value, the default, and NULL for nullable columns.
+func (f *rowField) cycleMode() {
+	switch {
+	case f.mode == valueMode:
+		f.setMode(defaultMode)
+	case f.mode == defaultMode && f.column.Nullable:
+		f.setMode(nullMode)
+	default:
+		f.setMode(valueMode)
+	}
+}
+
+// toggleMark marks the selected row of the preview, or unmarks it. Deleting removes the marked rows.
+func (tui *TUI) toggleMark() {
+	row, _, ok := tui.selectedCell()
+	if !ok {
+		return
+	}
+
+	tab := tui.currentTab()
+	if tab.marked == nil {
+		tab.marked = map[int]bool{}
+	}
+	if tab.marked[row] {
+		delete(tab.marked, row)
+	} else {
+		tab.marked[row] = true
+	}
+	tui.saveTabState()
+	tui.renderTab()
+	// Marking moves on, so that consecutive rows are marked by repeating the key.
+	if row+1 < len(tab.data) {
+		_, column := tui.PreviewTable.GetSelection()
+		tui.PreviewTable.Select(row+1, column)
+	}
+}
+
+// insertRow opens the row form for a new row of the previewed table.
+func (tui *TUI) insertRow() {
+	target, err := tui.editTarget()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+	tui.showRowForm(target, "Insert row", nil)
+}
+
+// duplicateRow opens the row form filled with the values of the selected row. Primary key columns start with
+// their default instead, as the copied key is taken and auto-incremented ids are generated anyway.
+func (tui *TUI) duplicateRow() {
+	row, _, ok := tui.selectedCell()
+	if !ok {
+		tui.showWarning("no row to duplicate")
+		return
+	}
+	target, err := tui.editTarget()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+	tui.showRowForm(target, fmt.Sprintf("Duplicate row %d", row), target.tab.data[row])
+}
+
+// showRowForm asks for the values of a new row, with a field per column of the table. Without values to start from,
+// columns with a default or accepting NULL start with their default. Ctrl-D switches a field between a value,
+// the default and NULL.
+func (tui *TUI) showRowForm(target editTarget, title string, values []*string) {
+	names := tui.resultColumns()
+	form := tview.NewForm().SetFieldStyle(tui.theme.Input).SetLabelColor(tui.theme.Title).
+		SetButtonStyle(tui.theme.Input).SetButtonActivatedStyle(tui.theme.Selected).SetItemPadding(0)
+
+	fields := make([]*rowField, len(target.columns))
+	for i, c := range target.columns {
+		field := &rowField{column: c, input: tview.NewInputField().SetLabel(c.Name + " " + c.Type)}
+		fields[i] = field
+		form.AddFormItem(field.input)
+
+		index := slices.Index(names, c.Name)
+		switch {
+		case values != nil && index >= 0 && !c.PrimaryKey:
+			if values[index] == nil {
+				field.setMode(nullMode)
+			} else {
+				field.input.SetText(*values[index])
+				field.setMode(valueMode)
+			}
+		case c.Default != nil || c.Nullable || values != nil:
+			field.setMode(defaultMode)
+		default:
+			field.setMode(valueMode)
+		}
+		field.input.SetChangedFunc(func(text string) {
+			if text != "" {
+				field.mode = valueMode
+				field.input.SetPlaceholder("empty string")
+			}
+		})
+	}
+
+	insert := func() {
+		tui.hideOverlay(rowFormPage)
+		var columns []string
+		var values []*string
+		for _, f := range fields {
+			switch f.mode {
+			case valueMode:
+				text := f.input.GetText()
+				columns, values = append(columns, f.column.Name), append(values, &text)
+			case nullMode:
+				columns, values = append(columns, f.column.Name), append(values, nil)
+			}
+		}
+		tui.insertValues(target, columns, values)
+	}
+	form.AddButton("Insert", insert)
+	form.AddButton("Cancel", func() { tui.hideOverlay(rowFormPage) })
+	form.SetCancelFunc(func() { tui.hideOverlay(rowFormPage) })
+	form.SetBorder(true).SetTitle(fmt.Sprintf("%s into %s [ Ctrl-D: value / default / NULL · Ctrl-S: insert · Esc: cancel ]", title, target.tab.table)).
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyCtrlS:
+			insert()
+		case tcell.KeyCtrlD:
+			if index, _ := form.GetFocusedItemIndex(); index >= 0 {
+				fields[index].cycleMode()
+			}
+		default:
+			return event
+		}
+		return nil
+	})
+
+	tui.showOverlay(rowFormPage, form, 100, len(fields)+5)
+}
+
+// insertValues stages a new row with the values of the columns. The other columns get their default values.
+func (tui *TUI) insertValues(target editTarget, columns []string, values []*string) {
+	names := tui.resultColumns()
+	row, set := make([]*string, len(names)), make([]bool, len(names))
+	for i, c := range columns {
+		if index := slices.Index(names, c); index >= 0 {
+			row[index], set[index] = values[i], true
+		}
+	}
+
+	tui.saveTabState()
+	target.tab.data = target.changes().Insert(target.tab.data, row, set)
+	target.tab.row = len(target.tab.data) - 1
+	tui.renderTab()
+}
+
+// deleteRows flags the marked rows of the preview, or the selected one when none is marked, for deletion.
+// Rows already flagged are kept again, and staged inserts are dropped.
+func (tui *TUI) deleteRows() {
+	target, err := tui.editTarget()
+	if err != nil {
+		tui.showWarning(err.Error())
+		return
+	}
+
+	var rows []int
+	for row := range target.tab.marked {
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		row, _, ok := tui.selectedCell()
+		if !ok {
+			tui.showWarning("no row to delete")
+			return
+		}
+		rows = append(rows, row)
+	}
+	slices.Sort(rows)
+
+	tui.saveTabState()
+	changes := target.changes()
+	// Dropping an inserted row moves the rows after it, so the rows are handled from the last one.
+	for i := len(rows) - 1; i >= 0; i-- {
+		target.tab.data = changes.ToggleDelete(target.tab.data, rows[i])
+	}
+	target.tab.marked = nil
+	target.tab.row = min(target.tab.row, len(target.tab.data)-1)
+	tui.renderTab()
+}
+
+// reloadPreview replaces the rows of the tab with a fresh preview of its table.
+func (tui *TUI) reloadPreview(tab *resultTab) {
+	data, err := tui.dc.Current().PreviewTable(tab.schema, tab.table, tab.preview)
+	if err != nil {
+		tui.showError(err)
+		return
+	}
+
+	tui.saveTabState()
+	tab.data, tab.marked, tab.changes = data, nil, nil
+	tui.renderTab()
+}
+
+// countRows returns the number of rows as text, e.g. "1 row" or "3 rows".
+func countRows(n int) string {
+	if n == 1 {
+		return "1 row"
+	}
+	return fmt.Sprintf("%d rows", n)
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowField_CycleMode(t *testing.T) {
+	def := "0"
+	field := &rowField{column: internal.Column{Name: "n", Nullable: true, Default: &def}, input: tview.NewInputField()}
+	field.input.SetText("42")
+
+	field.cycleMode()
+	assert.Equal(t, defaultMode, field.mode)
+	assert.Empty(t, field.input.GetText())
+	field.cycleMode()
+	assert.Equal(t, nullMode, field.mode)
+	field.cycleMode()
+	assert.Equal(t, valueMode, field.mode)
+
+	field.column.Nullable = false
+	field.cycleMode()
+	field.cycleMode()
+	assert.Equal(t, valueMode, field.mode, "NULL is skipped for columns which do not accept it")
+}
+
+func TestTUI_ToggleMark(t *testing.T) {
+	tui := newTestTUI(t)
+	*tui.currentTab() = resultTab{data: testData(3), row: 1}
+	tui.renderTab()
+
+	tui.toggleMark()
+	tui.toggleMark()
+	assert.Equal(t, map[int]bool{1: true, 2: true}, tui.currentTab().marked, "marking moves to the next row")
+	assert.Equal(t, tui.theme.Marked, tui.PreviewTable.GetCell(2, 0).Style)
+
+	tui.PreviewTable.Select(1, 0)
+	tui.toggleMark()
+	assert.Equal(t, map[int]bool{2: true}, tui.currentTab().marked)
+	assert.Equal(t, tui.theme.Text, tui.PreviewTable.GetCell(1, 0).Style)
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/snippets"
+	"github.com/kenanbek/dbui/internal/sqlparse"
+	"github.com/rivo/tview"
+)
+
+const paramsPage = "params"
+
+// currentType returns the type of the current data source, e.g. postgresql.
+func (tui *TUI) currentType() string {
+	return tui.typeOf(tui.dc.CurrentAlias())
+}
+
+// loadSnippets fills the Snippets view with the saved queries meant for the current data source.
+func (tui *TUI) loadSnippets() {
+	dir := tui.ac.Snippets()
+	if dir == "" {
+		var err error
+		if dir, err = snippets.DefaultDir(); err != nil {
+			tui.showError(err)
+			return
+		}
+	}
+
+	library, err := snippets.Load(dir)
+	if err != nil {
+		tui.showWarning(fmt.Sprintf("Some saved queries could not be loaded: %v", err))
+	}
+
+	alias, typ := tui.dc.CurrentAlias(), tui.currentType()
+	var applicable []snippets.Snippet
+	for _, s := range library {
+		if s.Applies(alias, typ) {
+			applicable = append(applicable, s)
+		}
+	}
+
+	tui.queueUpdateDraw(func() {
+		tui.library = applicable
+		tui.Snippets.Clear()
+		for _, s := range applicable {
+			tui.Snippets.AddItem(s.Name, s.Description, 0, nil)
+		}
+	})
+}
+
+func (tui *TUI) selectedSnippet() (snippets.Snippet, bool) {
+	i := tui.Snippets.GetCurrentItem()
+	if i < 0 || i >= len(tui.library) {
+		return snippets.Snippet{}, false
+	}
+
+	return tui.library[i], true
+}
+
+// editSelectedSnippet copies the query of the selected snippet into the Query editor.
+func (tui *TUI) editSelectedSnippet() {
+	if s, ok := tui.selectedSnippet(); ok {
+		tui.QueryEditor.SetText(s.SQL)
+		tui.setFocus(tui.QueryEditor)
+	}
+}
+
+func (tui *TUI) snippetSelected(int, string, string, rune) {
+	s, ok := tui.selectedSnippet()
+	if !ok {
+		return
+	}
+
+	params := s.Params()
+	if len(params) == 0 {
+		tui.runSnippet(s, nil)
+		return
+	}
+
+	// The values entered last time are offered again, as the same snippet often runs several times in a row.
+	form := tview.NewForm().SetFieldStyle(tui.theme.Input).SetLabelColor(tui.theme.Title).
+		SetButtonStyle(tui.theme.Input).SetButtonActivatedStyle(tui.theme.Selected)
+	for _, p := range params {
+		form.AddInputField(p, tui.paramValues[p], 40, nil, nil)
+	}
+	form.AddButton("Run", func() {
+		values := map[string]string{}
+		for i, p := range params {
+			values[p] = form.GetFormItem(i).(*tview.InputField).GetText()
+			tui.paramValues[p] = values[p]
+		}
+		tui.hideOverlay(paramsPage)
+		tui.runSnippet(s, values)
+	})
+	form.AddButton("Cancel", func() { tui.hideOverlay(paramsPage) })
+	form.SetCancelFunc(func() { tui.hideOverlay(paramsPage) })
+	form.SetBorder(true).SetTitle(fmt.Sprintf("%s [ Esc: cancel ]", s.Name)).
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Enter in the last field runs the snippet instead of moving to the buttons.
+		if index, _ := form.GetFocusedItemIndex(); event.Key() == tcell.KeyEnter && index == len(params)-1 {
+			form.GetButton(0).InputHandler()(event, func(tview.Primitive) {})
+			return nil
+		}
+		return event
+	})
+
+	tui.showOverlay(paramsPage, form, 60, 2*len(params)+5)
+}
+
+// runSnippet binds the values to the placeholders of the snippet and runs its statements.
+func (tui *TUI) runSnippet(s snippets.Snippet, values map[string]string) {
+	var statements []string
+	for _, statement := range sqlparse.Split(s.Bind(values, tui.currentType())) {
+		statements = append(statements, statement.Text)
+	}
+	tui.runStatements(s.Name, statements)
+}
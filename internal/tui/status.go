@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kenanbek/dbui/internal"
+)
+
+// status is what the status bar tells about the connection to the current data source.
+type status struct {
+	alias string
+	info  internal.ServerInfo
+	// latency is the round trip of a Ping to the data source.
+	latency time.Duration
+	// err is why the data source could not be reached, and infoErr why it could not be described although it was.
+	err, infoErr error
+}
+
+// loadStatus pings the data source and asks it about its server. It talks to the database, so it runs off the UI
+// goroutine.
+func loadStatus(alias string, ds internal.DataSource) status {
+	s := status{alias: alias}
+	started := time.Now()
+	if s.err = ds.Ping(); s.err != nil {
+		return s
+	}
+	s.latency = time.Since(started)
+	s.info, s.infoErr = ds.ServerInfo()
+
+	return s
+}
+
+// refreshStatus reloads the status of the current data source in the background and shows it once known. A status
+// arriving after the source was switched again is dropped.
+func (tui *TUI) refreshStatus() {
+	alias, ds := tui.dc.CurrentAlias(), tui.dc.Current()
+	tui.status = status{alias: alias}
+	tui.renderStatus()
+
+	go func() {
+		s := loadStatus(alias, ds)
+		tui.App.QueueUpdateDraw(func() {
+			if tui.dc.CurrentAlias() != alias {
+				return
+			}
+			tui.status = s
+			tui.renderStatus()
+		})
+	}()
+}
+
+// transactionState names the transaction mode the queries of the data source run in.
+func (tui *TUI) transactionState(alias string) string {
+	switch {
+	case tui.aborted[alias]:
+		return "transaction aborted"
+	case tui.inTransaction(alias):
+		return "transaction open"
+	default:
+		return "autocommit"
+	}
+}
+
+// statusText renders the status line, e.g. "pg · PostgreSQL 16.2 · postgres · schema public · 2 ms · autocommit ·
+// read-write".
+func (tui *TUI) statusText() string {
+	s := tui.status
+	parts := []string{s.alias}
+	if s.err != nil {
+		return strings.Join(append(parts, "unreachable: "+s.err.Error()), " · ")
+	}
+	if s.info.Engine == "" && s.infoErr == nil {
+		return strings.Join(append(parts, "connecting…"), " · ")
+	}
+
+	if s.infoErr != nil {
+		parts = append(parts, "server unknown: "+s.infoErr.Error())
+	} else {
+		parts = append(parts, strings.TrimSpace(s.info.Engine+" "+s.info.Version))
+	}
+	if s.info.User != "" {
+		parts = append(parts, s.info.User)
+	}
+	if schema, err := tui.getSelectedSchema(); err == nil && schema != "" {
+		parts = append(parts, "schema "+schema)
+	}
+	parts = append(parts, formatLatency(s.latency), tui.transactionState(s.alias))
+	switch {
+	case s.infoErr != nil:
+		// Whether the session can change data is part of what could not be read.
+	case s.info.ReadOnly:
+		parts = append(parts, "read-only")
+	default:
+		parts = append(parts, "read-write")
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// renderStatus shows the status in the status bar, in the error colour while the data source is unreachable, and in
+// the warning colour when it could not be described.
+func (tui *TUI) renderStatus() {
+	style := tui.theme.Footer
+	switch {
+	case tui.status.err != nil:
+		style = tui.theme.Error
+	case tui.status.infoErr != nil:
+		style = tui.theme.Warning
+	}
+	tui.StatusBar.SetText(" " + tui.statusText()).SetTextStyle(style)
+}
+
+// formatLatency renders a round trip in whole milliseconds; faster ones are shown as below one.
+func formatLatency(d time.Duration) string {
+	if d < time.Millisecond {
+		return "<1 ms"
+	}
+	return fmt.Sprintf("%d ms", d.Milliseconds())
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+type statusDataSource struct {
+	internal.DataSource
+	pingErr, infoErr error
+	info             internal.ServerInfo
+}
+
+func (d statusDataSource) Ping() error {
+	return d.pingErr
+}
+
+func (d statusDataSource) ServerInfo() (internal.ServerInfo, error) {
+	return d.info, d.infoErr
+}
+
+func TestLoadStatus(t *testing.T) {
+	info := internal.ServerInfo{Engine: "PostgreSQL", Version: "16.2", User: "postgres"}
+	s := loadStatus("pg", statusDataSource{info: info})
+	assert.Equal(t, "pg", s.alias)
+	assert.Equal(t, info, s.info)
+	assert.NoError(t, s.err)
+
+	s = loadStatus("pg", statusDataSource{pingErr: errors.New("connection refused"), info: info})
+	assert.EqualError(t, s.err, "connection refused")
+	assert.Empty(t, s.info, "an unreachable server is not asked about itself")
+
+	s = loadStatus("my", statusDataSource{infoErr: errors.New("unknown system variable")})
+	assert.NoError(t, s.err, "the server answered the ping")
+	assert.EqualError(t, s.infoErr, "unknown system variable")
+}
+
+func TestTUI_StatusText(t *testing.T) {
+	tui := newTestTUI(t)
+	tui.StatusBar = tview.NewTextView()
+	tui.Schemas = tview.NewList().AddItem("public", "", 0, nil)
+
+	tui.status = status{alias: "pg"}
+	assert.Equal(t, "pg · connecting…", tui.statusText())
+
+	tui.status = status{
+		alias:   "pg",
+		info:    internal.ServerInfo{Engine: "PostgreSQL", Version: "16.2", User: "postgres"},
+		latency: 12 * time.Millisecond,
+	}
+	assert.Equal(t, "pg · PostgreSQL 16.2 · postgres · schema public · 12 ms · autocommit · read-write", tui.statusText())
+
+	tui.status = status{alias: "demo", info: internal.ServerInfo{Engine: "Dummy", Version: "demo", ReadOnly: true}, latency: time.Microsecond}
+	tui.Schemas.Clear()
+	assert.Equal(t, "demo · Dummy demo · <1 ms · autocommit · read-only", tui.statusText())
+
+	tui.status = status{alias: "my", latency: 3 * time.Millisecond, infoErr: errors.New("unknown system variable")}
+	tui.renderStatus()
+	assert.Equal(t, " my · server unknown: unknown system variable · 3 ms · autocommit", tui.StatusBar.GetText(false))
+
+	tui.status = status{alias: "my", err: errors.New("connection refused")}
+	tui.renderStatus()
+	assert.Equal(t, " my · unreachable: connection refused", tui.StatusBar.GetText(false))
+}
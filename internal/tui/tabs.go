@@ -0,0 +1,289 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/changeset"
+	"github.com/kenanbek/dbui/internal/layout"
+	"github.com/rivo/tview"
+	"github.com/rivo/uniseg"
+)
+
+const renamePage = "rename"
+
+// resultTab is a result shown in the preview. Every tab keeps where its data came from
+// and, while another tab is shown, its scroll position and selection.
+type resultTab struct {
+	// name is set by renaming the tab and replaces the label of its results.
+	name  string
+	label string
+
+	alias, schema, table, sql string
+	data                      [][]*string
+	// preview filters, sorts and pages the rows of a table preview.
+	preview internal.Preview
+	// first is the number of the first row of the page, counting from 1, and estimate the estimated number
+	// of rows of the table, or -1 if unknown.
+	first    int
+	estimate int64
+
+	rowOffset, columnOffset int
+	row, column             int
+	// marked holds the indexes of the marked rows of data.
+	marked map[int]bool
+	// changes holds the staged changes of a table preview.
+	changes *changeset.Changeset
+	// layout tells how the columns are shown, and columns holds the indexes of the shown columns of data
+	// in the order they are shown.
+	layout  layout.Layout
+	columns []int
+}
+
+// title returns the name of the tab shown in the tab bar.
+func (t *resultTab) title() string {
+	switch {
+	case t.name != "":
+		return t.name
+	case t.label != "":
+		return t.label
+	default:
+		return "empty"
+	}
+}
+
+// TabBar shows the names of the result tabs in a single line, highlighting the active one.
+type TabBar struct {
+	*tview.Box
+
+	theme  *Theme
+	titles []string
+	active int
+	// first is the index of the leftmost visible tab.
+	first int
+	// selected is called with the index of a tab clicked with the mouse.
+	selected func(index int)
+}
+
+// NewTabBar returns an empty tab bar.
+func NewTabBar(theme *Theme) *TabBar {
+	return &TabBar{Box: tview.NewBox(), theme: theme}
+}
+
+// SetTabs sets the tab titles and the index of the active tab.
+func (b *TabBar) SetTabs(titles []string, active int) *TabBar {
+	b.titles, b.active = titles, active
+	b.first = min(b.first, max(0, len(titles)-1))
+	return b
+}
+
+// SetSelectedFunc sets the function called when a tab is clicked.
+func (b *TabBar) SetSelectedFunc(f func(index int)) *TabBar {
+	b.selected = f
+	return b
+}
+
+// label returns the text of the tab at the index, e.g. " 2 query ".
+func (b *TabBar) label(index int) string {
+	return fmt.Sprintf(" %d %s ", index+1, b.titles[index])
+}
+
+// Draw draws this primitive onto the screen. Tabs which do not fit are cut off, scrolling to keep the active tab visible.
+func (b *TabBar) Draw(screen tcell.Screen) {
+	b.DrawForSubclass(screen, b)
+	x, y, width, height := b.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	if len(b.titles) == 0 {
+		return
+	}
+	b.first = min(b.first, b.active)
+	used := 0
+	for i := b.first; i <= b.active; i++ {
+		used += uniseg.StringWidth(b.label(i)) + 1
+	}
+	for b.first < b.active && used > width {
+		used -= uniseg.StringWidth(b.label(b.first)) + 1
+		b.first++
+	}
+
+	col := x
+	for i := b.first; i < len(b.titles) && col < x+width; i++ {
+		style := b.theme.Secondary
+		if i == b.active {
+			style = b.theme.Selected
+		}
+		printCells(screen, b.label(i), col, y, x+width-col, style)
+		col += uniseg.StringWidth(b.label(i)) + 1
+	}
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (b *TabBar) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return b.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, _ func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		mx, my := event.Position()
+		if action != tview.MouseLeftClick || !b.InRect(mx, my) || b.selected == nil {
+			return false, nil
+		}
+
+		x, _, _, _ := b.GetInnerRect()
+		for i := b.first; i < len(b.titles); i++ {
+			w := uniseg.StringWidth(b.label(i)) + 1
+			if mx < x+w {
+				b.selected(i)
+				return true, nil
+			}
+			x += w
+		}
+		return true, nil
+	})
+}
+
+func (tui *TUI) currentTab() *resultTab {
+	return tui.tabs[tui.activeTab]
+}
+
+// saveTabState remembers the scroll position and selection of the preview in the active tab.
+func (tui *TUI) saveTabState() {
+	tab := tui.currentTab()
+	tab.rowOffset, tab.columnOffset = tui.PreviewTable.GetOffset()
+	tab.row, tab.column = tui.PreviewTable.GetSelection()
+}
+
+// renderTab shows the active tab in the preview and the tab bar.
+func (tui *TUI) renderTab() {
+	tab := tui.currentTab()
+	tui.PreviewTable.Clear()
+
+	tab.columns = tab.layout.Columns(tui.resultColumns())
+	for i, row := range tab.data {
+		for column, j := range tab.columns {
+			col := row[j]
+			var cellValue string
+			var cellStyle = tui.theme.Text
+			var notSelectable = false
+
+			if col != nil {
+				cellValue = *col
+			}
+			if i == 0 {
+				notSelectable = true
+				cellStyle = tui.theme.Header
+				cellValue += sortArrow(tab.preview, cellValue)
+			} else if tab.marked[i] {
+				cellStyle = tui.theme.Marked
+			} else if tab.changes != nil && tab.changes.State(i) == changeset.Deleted {
+				cellStyle = tui.theme.Deleted
+			} else if tab.changes != nil && tab.changes.Changed(i, j) {
+				cellStyle = tui.theme.Changed
+			}
+
+			tui.PreviewTable.SetCell(
+				i, column,
+				tview.NewTableCell(cellValue).SetStyle(cellStyle).SetSelectable(!notSelectable).SetMaxWidth(tab.layout.MaxWidth),
+			)
+		}
+	}
+
+	title := tui.title("Preview", KeyPreviewOp)
+	if tab.label != "" {
+		title = fmt.Sprintf("%s: %s (%s · %s)%s", title, tab.label, tab.alias, tab.schema, pageTitle(tab)+previewTitle(tab.preview))
+	}
+	if tab.changes != nil && tab.changes.Len() > 0 {
+		title += fmt.Sprintf(" · %s pending", pendingChanges(tab.changes.Len()))
+	}
+	tui.PreviewTable.SetTitle(title)
+	tui.PreviewTable.SetFixed(1, min(tab.layout.Frozen, len(tab.columns)))
+	tui.PreviewTable.SetSelectable(true, true)
+	tui.PreviewTable.Select(tab.row, tab.column)
+	tui.PreviewTable.SetOffset(tab.rowOffset, tab.columnOffset)
+
+	titles := make([]string, len(tui.tabs))
+	for i, t := range tui.tabs {
+		titles[i] = t.title()
+	}
+	tui.TabBar.SetTabs(titles, tui.activeTab)
+}
+
+// showData shows the result in the active tab, replacing its previous result. A tab with pending changes is kept,
+// and the result opens in a new tab instead. The result takes the place of the previous one rather than being
+// copied over it, so an editor still open on the previous result can tell that it is gone.
+func (tui *TUI) showData(result resultTab) {
+	result.alias = tui.dc.CurrentAlias()
+	result.layout = tui.layoutOf(&result)
+	tui.queueUpdateDraw(func() {
+		if changes := tui.currentTab().changes; changes != nil && changes.Len() > 0 {
+			tui.openTab()
+		}
+		result.name, result.row = tui.currentTab().name, 1
+		tui.tabs[tui.activeTab] = &result
+		tui.renderTab()
+	})
+}
+
+// shown reports whether the tab is still open and shows the result it was made for.
+func (tui *TUI) shown(tab *resultTab) bool {
+	return slices.Contains(tui.tabs, tab)
+}
+
+// openTab adds an empty tab after the active one and shows it; the next result goes there.
+func (tui *TUI) openTab() {
+	tui.saveTabState()
+	tui.activeTab++
+	tui.tabs = append(tui.tabs[:tui.activeTab], append([]*resultTab{{}}, tui.tabs[tui.activeTab:]...)...)
+	tui.renderTab()
+}
+
+// closeTab removes the active tab. The last tab is emptied instead. Tabs with pending changes are not closed.
+func (tui *TUI) closeTab() {
+	if changes := tui.currentTab().changes; changes != nil && changes.Len() > 0 {
+		tui.showWarning(fmt.Sprintf("The tab has %s pending: apply them with %s or discard them with %s first",
+			pendingChanges(changes.Len()), tui.keys.Label(KeyReviewChangesOp), tui.keys.Label(KeyDiscardChangesOp)))
+		return
+	}
+	if len(tui.tabs) == 1 {
+		tui.tabs[0] = &resultTab{}
+	} else {
+		tui.tabs = append(tui.tabs[:tui.activeTab], tui.tabs[tui.activeTab+1:]...)
+		tui.activeTab = min(tui.activeTab, len(tui.tabs)-1)
+	}
+	tui.renderTab()
+}
+
+// switchTab shows the tab at the index.
+func (tui *TUI) switchTab(index int) {
+	if index == tui.activeTab || index < 0 || index >= len(tui.tabs) {
+		return
+	}
+	tui.saveTabState()
+	tui.activeTab = index
+	tui.renderTab()
+}
+
+// cycleTab shows the next (step 1) or previous (step -1) tab, wrapping around.
+func (tui *TUI) cycleTab(step int) {
+	tui.switchTab((tui.activeTab + step + len(tui.tabs)) % len(tui.tabs))
+}
+
+// renameTab asks for a new name of the active tab. An empty name restores the name of its result.
+func (tui *TUI) renameTab() {
+	tab := tui.currentTab()
+	input := tview.NewInputField().SetText(tab.name).SetFieldStyle(tui.theme.Input).
+		SetLabel(strconv.Itoa(tui.activeTab+1) + " ").SetLabelStyle(tcell.StyleDefault.Foreground(tui.theme.Title))
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			tab.name = input.GetText()
+			tui.renderTab()
+		}
+		tui.hideOverlay(renamePage)
+	})
+	input.SetBorder(true).SetTitle("Rename tab [ Enter: save · Esc: cancel ]").
+		SetBorderStyle(tui.theme.FocusBorder).SetTitleColor(tui.theme.Title)
+
+	tui.showOverlay(renamePage, input, 50, 3)
+}
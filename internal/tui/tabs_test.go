@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTUI_Tabs(t *testing.T) {
+	tui := newTestTUI(t)
+	*tui.currentTab() = resultTab{label: "preview a", alias: "pg", schema: "public", table: "a", data: testData(100), row: 1}
+	tui.renderTab()
+	tui.PreviewTable.Select(40, 0)
+
+	tui.openTab()
+	assert.Equal(t, 1, tui.activeTab)
+	assert.Equal(t, 0, tui.PreviewTable.GetRowCount(), "a new tab is empty")
+	*tui.currentTab() = resultTab{label: "query", sql: "select 1", data: testData(1), row: 1}
+	tui.renderTab()
+	assert.Equal(t, []string{"preview a", "query"}, tui.TabBar.titles)
+
+	tui.cycleTab(1)
+	assert.Equal(t, 0, tui.activeTab, "cycling wraps around")
+	row, _ := tui.PreviewTable.GetSelection()
+	assert.Equal(t, 40, row, "the selection is restored")
+	assert.Equal(t, 101, tui.PreviewTable.GetRowCount())
+
+	tui.cycleTab(-1)
+	assert.Equal(t, 1, tui.activeTab)
+	tui.currentTab().name = "mine"
+	tui.renderTab()
+	assert.Equal(t, []string{"preview a", "mine"}, tui.TabBar.titles, "a renamed tab shows its name")
+
+	tui.closeTab()
+	assert.Equal(t, 0, tui.activeTab)
+	assert.Len(t, tui.tabs, 1)
+	tui.closeTab()
+	assert.Len(t, tui.tabs, 1, "closing the last tab empties it")
+	assert.Equal(t, []string{"empty"}, tui.TabBar.titles)
+}
@@ -0,0 +1,289 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/kenanbek/dbui/internal/sqlparse"
+	"github.com/rivo/tview"
+)
+
+// Theme holds the styles of every colored element of the user interface.
+type Theme struct {
+	Background  tcell.Color
+	Text        tcell.Style
+	Secondary   tcell.Style
+	Border      tcell.Style
+	FocusBorder tcell.Style
+	Title       tcell.Color
+	Header      tcell.Style
+	Selected    tcell.Style
+	Input       tcell.Style
+	Footer      tcell.Style
+	Message     tcell.Style
+	Warning     tcell.Style
+	Error       tcell.Style
+	// Marked highlights the rows marked in the preview.
+	Marked tcell.Style
+	// Changed highlights the cells with pending changes in the preview, Deleted the rows pending deletion.
+	Changed tcell.Style
+	Deleted tcell.Style
+
+	// Query editor styles.
+	Keyword         tcell.Style
+	String          tcell.Style
+	Number          tcell.Style
+	Comment         tcell.Style
+	LineNumber      tcell.Style
+	MatchingBracket tcell.Style
+}
+
+// DefaultTheme is the theme used when the configuration does not name one.
+const DefaultTheme = "dark"
+
+// paletteKeys lists the colors a palette defines. Custom palettes may additionally name a "base" theme they extend.
+var paletteKeys = []string{
+	"background", "text", "secondaryText", "border", "focusBorder", "title", "header",
+	"selectedText", "selectedBackground", "inputText", "inputBackground",
+	"footer", "message", "warning", "error", "marked", "changed", "deleted",
+	"keyword", "string", "number", "comment", "lineNumber", "matchingBracket",
+}
+
+// builtinPalettes are the themes shipped with dbui. Colors are tcell color names or #rrggbb values.
+var builtinPalettes = map[string]map[string]string{
+	"dark": {
+		"background": "black", "text": "white", "secondaryText": "dimgray",
+		"border": "white", "focusBorder": "green", "title": "white", "header": "yellow",
+		"selectedText": "black", "selectedBackground": "white", "inputText": "white", "inputBackground": "blue",
+		"footer": "gray", "message": "green", "warning": "yellow", "error": "red", "marked": "#5f0000",
+		"changed": "#5f5f00", "deleted": "#3a3a3a",
+		"keyword": "#5fafff", "string": "#87d787", "number": "#d7af5f", "comment": "gray", "lineNumber": "dimgray",
+		"matchingBracket": "#5f5f87",
+	},
+	"light": {
+		"background": "white", "text": "black", "secondaryText": "gray",
+		"border": "darkgray", "focusBorder": "#005fd7", "title": "black", "header": "#875f00",
+		"selectedText": "white", "selectedBackground": "#005fd7", "inputText": "black", "inputBackground": "#d0d0d0",
+		"footer": "dimgray", "message": "#008700", "warning": "#af5f00", "error": "#af0000", "marked": "#ffd7d7",
+		"changed": "#ffffaf", "deleted": "#d0d0d0",
+		"keyword": "#0000af", "string": "#008700", "number": "#af5f00", "comment": "gray", "lineNumber": "darkgray",
+		"matchingBracket": "#d7d7ff",
+	},
+	"high-contrast": {
+		"background": "black", "text": "white", "secondaryText": "white",
+		"border": "white", "focusBorder": "yellow", "title": "white", "header": "aqua",
+		"selectedText": "black", "selectedBackground": "yellow", "inputText": "white", "inputBackground": "navy",
+		"footer": "white", "message": "lime", "warning": "yellow", "error": "red", "marked": "maroon",
+		"changed": "olive", "deleted": "gray",
+		"keyword": "aqua", "string": "lime", "number": "yellow", "comment": "silver", "lineNumber": "white",
+		"matchingBracket": "blue",
+	},
+}
+
+// NewTheme resolves the named theme among the built-in and the custom palettes.
+// With noColor set, the NO_COLOR convention is honored: every color is dropped and
+// the elements are told apart by attributes like bold and reverse only.
+func NewTheme(name string, custom map[string]map[string]string, noColor bool) (*Theme, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+
+	palette, err := resolvePalette(name, custom, nil)
+	if err != nil {
+		return nil, err
+	}
+	if noColor {
+		return monochromeTheme(), nil
+	}
+
+	c := func(key string) tcell.Color { return palette[key] }
+	bg := c("background")
+	style := func(key string) tcell.Style { return tcell.StyleDefault.Foreground(c(key)).Background(bg) }
+
+	return &Theme{
+		Background:  bg,
+		Text:        style("text"),
+		Secondary:   style("secondaryText"),
+		Border:      style("border"),
+		FocusBorder: style("focusBorder"),
+		Title:       c("title"),
+		Header:      style("header"),
+		Selected:    tcell.StyleDefault.Foreground(c("selectedText")).Background(c("selectedBackground")),
+		Input:       tcell.StyleDefault.Foreground(c("inputText")).Background(c("inputBackground")),
+		Footer:      style("footer"),
+		Message:     style("message"),
+		Warning:     style("warning"),
+		Error:       style("error"),
+		Marked:      style("text").Background(c("marked")),
+		Changed:     style("text").Background(c("changed")),
+		Deleted:     style("secondaryText").Background(c("deleted")).StrikeThrough(true),
+
+		Keyword:         style("keyword").Bold(true),
+		String:          style("string"),
+		Number:          style("number"),
+		Comment:         style("comment").Italic(true),
+		LineNumber:      style("lineNumber"),
+		MatchingBracket: style("text").Background(c("matchingBracket")).Bold(true),
+	}, nil
+}
+
+// ValidateTheme checks that the named theme exists and that the custom palettes are valid.
+func ValidateTheme(name string, custom map[string]map[string]string) error {
+	names := make([]string, 0, len(custom))
+	for n := range custom {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		if _, err := resolvePalette(n, custom, nil); err != nil {
+			return err
+		}
+	}
+	_, err := NewTheme(name, custom, false)
+
+	return err
+}
+
+func monochromeTheme() *Theme {
+	plain := tcell.StyleDefault
+	return &Theme{
+		Background:  tcell.ColorDefault,
+		Text:        plain,
+		Secondary:   plain.Dim(true),
+		Border:      plain,
+		FocusBorder: plain.Bold(true),
+		Title:       tcell.ColorDefault,
+		Header:      plain.Bold(true).Underline(true),
+		Selected:    plain.Reverse(true),
+		Input:       plain.Underline(true),
+		Footer:      plain.Dim(true),
+		Message:     plain,
+		Warning:     plain.Bold(true),
+		Error:       plain.Bold(true).Reverse(true),
+		Marked:      plain.Italic(true).Underline(true),
+		Changed:     plain.Bold(true).Italic(true),
+		Deleted:     plain.Dim(true).StrikeThrough(true),
+
+		Keyword:         plain.Bold(true),
+		String:          plain,
+		Number:          plain,
+		Comment:         plain.Dim(true),
+		LineNumber:      plain.Dim(true),
+		MatchingBracket: plain.Underline(true).Bold(true),
+	}
+}
+
+// tokenStyle returns the syntax highlighting style of an SQL token.
+func (t *Theme) tokenStyle(kind sqlparse.TokenKind) tcell.Style {
+	switch kind {
+	case sqlparse.Keyword:
+		return t.Keyword
+	case sqlparse.String:
+		return t.String
+	case sqlparse.Number, sqlparse.Parameter:
+		return t.Number
+	case sqlparse.Comment:
+		return t.Comment
+	default:
+		return t.Text
+	}
+}
+
+// resolvePalette merges a custom palette over its base theme, following base chains.
+func resolvePalette(name string, custom map[string]map[string]string, chain []string) (map[string]tcell.Color, error) {
+	for _, n := range chain {
+		if n == name {
+			return nil, fmt.Errorf("theme %q: base cycle: %s", name, strings.Join(append(chain, name), " -> "))
+		}
+	}
+	chain = append(chain, name)
+
+	spec, isCustom := custom[name]
+	if !isCustom {
+		builtin, ok := builtinPalettes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown theme %q", name)
+		}
+		return parsePalette(name, builtin)
+	}
+
+	var (
+		palette map[string]tcell.Color
+		err     error
+	)
+	base, hasBase := spec["base"]
+	switch builtin, isBuiltin := builtinPalettes[name]; {
+	case hasBase:
+		palette, err = resolvePalette(base, custom, chain)
+	case isBuiltin:
+		// A custom palette named after a built-in theme tweaks that theme.
+		palette, err = parsePalette(name, builtin)
+	default:
+		palette, err = resolvePalette(DefaultTheme, custom, chain)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[string]string{}
+	for k, v := range spec {
+		if k != "base" {
+			overrides[k] = v
+		}
+	}
+	parsed, err := parsePalette(name, overrides)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range parsed {
+		palette[k] = v
+	}
+
+	return palette, nil
+}
+
+func parsePalette(name string, spec map[string]string) (map[string]tcell.Color, error) {
+	palette := map[string]tcell.Color{}
+	for key, value := range spec {
+		if !slices.Contains(paletteKeys, key) {
+			return nil, fmt.Errorf("theme %q: unknown color key %q, expected one of: %s", name, key, strings.Join(paletteKeys, ", "))
+		}
+
+		color := tcell.GetColor(value)
+		if color == tcell.ColorDefault && value != "default" {
+			return nil, fmt.Errorf("theme %q: %s: unknown color %q", name, key, value)
+		}
+		palette[key] = color
+	}
+
+	return palette, nil
+}
+
+// applyGlobalStyles sets the tview defaults, used by every primitive created afterwards.
+func (t *Theme) applyGlobalStyles() {
+	text, _, _ := t.Text.Decompose()
+	header, _, _ := t.Header.Decompose()
+	secondary, _, _ := t.Secondary.Decompose()
+	border, _, _ := t.Border.Decompose()
+	message, _, _ := t.Message.Decompose()
+	selectedText, selectedBg, _ := t.Selected.Decompose()
+	_, inputBg, _ := t.Input.Decompose()
+
+	tview.Styles = tview.Theme{
+		PrimitiveBackgroundColor:    t.Background,
+		ContrastBackgroundColor:     inputBg,
+		MoreContrastBackgroundColor: selectedBg,
+		BorderColor:                 border,
+		TitleColor:                  t.Title,
+		GraphicsColor:               border,
+		PrimaryTextColor:            text,
+		SecondaryTextColor:          header,
+		TertiaryTextColor:           message,
+		InverseTextColor:            selectedText,
+		ContrastSecondaryTextColor:  secondary,
+	}
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinPalettes(t *testing.T) {
+	for name, palette := range builtinPalettes {
+		t.Run(name, func(t *testing.T) {
+			assert.Len(t, palette, len(paletteKeys), "built-in themes define every color")
+			_, err := NewTheme(name, nil, false)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewTheme(t *testing.T) {
+	theme, err := NewTheme("", nil, false)
+	require.NoError(t, err)
+	fg, bg, _ := theme.Header.Decompose()
+	assert.Equal(t, tcell.ColorYellow, fg, "dark is the default theme")
+	assert.Equal(t, tcell.ColorBlack, bg)
+
+	custom := map[string]map[string]string{
+		"solarized": {"base": "light", "header": "#b58900"},
+		"mine":      {"base": "solarized", "error": "fuchsia"},
+		"dark":      {"focusBorder": "aqua"},
+		"plain":     {"text": "silver"},
+	}
+
+	theme, err = NewTheme("mine", custom, false)
+	require.NoError(t, err)
+	fg, bg, _ = theme.Header.Decompose()
+	assert.Equal(t, tcell.GetColor("#b58900"), fg, "colors are inherited through the base chain")
+	assert.Equal(t, tcell.ColorWhite, bg)
+	fg, _, _ = theme.Error.Decompose()
+	assert.Equal(t, tcell.ColorFuchsia, fg)
+
+	theme, err = NewTheme("dark", custom, false)
+	require.NoError(t, err)
+	fg, _, _ = theme.FocusBorder.Decompose()
+	assert.Equal(t, tcell.ColorAqua, fg, "a custom palette named after a built-in one tweaks it")
+	fg, _, _ = theme.Header.Decompose()
+	assert.Equal(t, tcell.ColorYellow, fg)
+
+	theme, err = NewTheme("plain", custom, false)
+	require.NoError(t, err)
+	fg, _, _ = theme.FocusBorder.Decompose()
+	assert.Equal(t, tcell.ColorAqua, fg, "palettes without base extend the (customized) default theme")
+}
+
+func TestNewTheme_NoColor(t *testing.T) {
+	theme, err := NewTheme("light", nil, true)
+	require.NoError(t, err)
+
+	for _, style := range []tcell.Style{theme.Text, theme.Header, theme.Selected, theme.Error, theme.FocusBorder} {
+		fg, bg, _ := style.Decompose()
+		assert.Equal(t, tcell.ColorDefault, fg)
+		assert.Equal(t, tcell.ColorDefault, bg)
+	}
+	_, _, attrs := theme.Selected.Decompose()
+	assert.NotZero(t, attrs&tcell.AttrReverse)
+	_, _, attrs = theme.Header.Decompose()
+	assert.NotZero(t, attrs&tcell.AttrBold)
+
+	_, err = NewTheme("ghost", nil, true)
+	assert.Error(t, err, "unknown themes are reported even with NO_COLOR")
+}
+
+func TestValidateTheme(t *testing.T) {
+	assert.NoError(t, ValidateTheme("high-contrast", nil))
+	assert.EqualError(t, ValidateTheme("ghost", nil), `unknown theme "ghost"`)
+	assert.EqualError(t, ValidateTheme("dark", map[string]map[string]string{"unused": {"header": "yelow"}}),
+		`theme "unused": header: unknown color "yelow"`)
+	assert.ErrorContains(t, ValidateTheme("a", map[string]map[string]string{"a": {"base": "b"}, "b": {"base": "a"}}), "base cycle")
+	assert.ErrorContains(t, ValidateTheme("a", map[string]map[string]string{"a": {"headr": "red"}}), `unknown color key "headr"`)
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kenanbek/dbui/internal"
+)
+
+// beginTransaction opens a transaction on the current data source. Queries, previews and applied changes run in it
+// until it is committed or rolled back.
+func (tui *TUI) beginTransaction() {
+	alias, ds := tui.dc.CurrentAlias(), tui.dc.Current()
+	if err := ds.Begin(); err != nil {
+		tui.showError(err)
+		return
+	}
+
+	if tui.transactions == nil {
+		tui.transactions = map[string]internal.DataSource{}
+	}
+	tui.transactions[alias] = ds
+	tui.renderTransaction()
+	tui.showMessage(fmt.Sprintf("Transaction started on %s, commit with %s or roll back with %s",
+		alias, tui.keys.Label(KeyCommitOp), tui.keys.Label(KeyRollbackOp)))
+}
+
+// commitTransaction commits the transaction of the current data source.
+func (tui *TUI) commitTransaction() {
+	tui.endTransaction(internal.DataSource.Commit, "Transaction committed")
+}
+
+// rollbackTransaction rolls the transaction of the current data source back and reloads the previewed table, which
+// may show changes made in it.
+func (tui *TUI) rollbackTransaction() {
+	if !tui.endTransaction(internal.DataSource.Rollback, "Transaction rolled back") {
+		return
+	}
+	tab := tui.currentTab()
+	if tab.table != "" && tab.alias == tui.dc.CurrentAlias() && (tab.changes == nil || tab.changes.Len() == 0) {
+		tui.reloadPreview(tab)
+	}
+}
+
+// endTransaction commits or rolls back the transaction of the current data source. It is over either way, even when
+// ending it fails.
+func (tui *TUI) endTransaction(end func(internal.DataSource) error, done string) bool {
+	alias := tui.dc.CurrentAlias()
+	err := end(tui.dc.Current())
+	delete(tui.transactions, alias)
+	delete(tui.aborted, alias)
+	tui.renderTransaction()
+	if errors.Is(err, internal.ErrNoTransaction) {
+		tui.showWarning(fmt.Sprintf("no transaction is open on %s, begin one with %s", alias, tui.keys.Label(KeyBeginOp)))
+		return false
+	}
+	if err != nil {
+		tui.showError(err)
+		return false
+	}
+
+	tui.showMessage(done)
+	return true
+}
+
+// abortTransaction flags the transaction of the current data source as aborted: it only takes a rollback.
+func (tui *TUI) abortTransaction() {
+	alias := tui.dc.CurrentAlias()
+	if !tui.inTransaction(alias) {
+		return
+	}
+
+	if tui.aborted == nil {
+		tui.aborted = map[string]bool{}
+	}
+	tui.aborted[alias] = true
+	tui.renderTransaction()
+}
+
+// inTransaction reports whether the data source has a transaction open.
+func (tui *TUI) inTransaction(alias string) bool {
+	_, ok := tui.transactions[alias]
+	return ok
+}
+
+// openTransactions returns the aliases of the data sources with a transaction open, sorted.
+func (tui *TUI) openTransactions() []string {
+	var aliases []string
+	for alias := range tui.transactions {
+		aliases = append(aliases, alias)
+	}
+	slices.Sort(aliases)
+	return aliases
+}
+
+// renderTransaction shows whether the current data source has a transaction open, in the status bar and in the
+// title of the Query editor.
+func (tui *TUI) renderTransaction() {
+	title := tui.title("Query", KeyQueryOp)
+	if alias := tui.dc.CurrentAlias(); tui.inTransaction(alias) {
+		title += " · " + tui.transactionState(alias)
+	}
+	tui.QueryEditor.SetTitle(title)
+	tui.renderStatus()
+}
+
+// quit exits the application. Open transactions are listed for confirmation first, and rolled back on exit.
+func (tui *TUI) quit() {
+	aliases := tui.openTransactions()
+	if len(aliases) == 0 {
+		tui.App.Stop()
+		return
+	}
+
+	viewer := NewViewer()
+	viewer.Write(fmt.Sprintf("Uncommitted transactions are open on %s.\nQuitting rolls them back.", strings.Join(aliases, ", ")),
+		tui.theme.Warning)
+	tui.confirm("Quit", viewer, func() {
+		for _, alias := range aliases {
+			_ = tui.transactions[alias].Rollback()
+		}
+		tui.App.Stop()
+	})
+}
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/eventlog"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+)
+
+// txDataSource keeps track of its session transaction like the SQL data sources do.
+type txDataSource struct {
+	fakeDataSource
+	open bool
+}
+
+func (d *txDataSource) Begin() error {
+	if d.open {
+		return internal.ErrTransactionOpen
+	}
+	d.open = true
+	return nil
+}
+
+func (d *txDataSource) Commit() error {
+	if !d.open {
+		return internal.ErrNoTransaction
+	}
+	d.open = false
+	return nil
+}
+
+func (d *txDataSource) Rollback() error {
+	return d.Commit()
+}
+
+func TestTUI_Transaction(t *testing.T) {
+	ds := &txDataSource{}
+	tui := newTestTUI(t)
+	tui.App = tview.NewApplication()
+	tui.dc = fakeController{alias: "pg", ds: ds}
+	tui.QueryEditor = newTestEditor("")
+	tui.StatusBar = tview.NewTextView()
+	tui.status = status{alias: "pg", info: internal.ServerInfo{Engine: "PostgreSQL"}}
+
+	tui.beginTransaction()
+	assert.True(t, ds.open)
+	assert.True(t, tui.inTransaction("pg"))
+	assert.Equal(t, "Query [ Ctrl-Q ] · transaction open", tui.QueryEditor.GetTitle())
+	assert.Contains(t, tui.statusText(), "· transaction open ·")
+
+	tui.beginTransaction()
+	entries := tui.messages.Entries()
+	assert.Equal(t, eventlog.Error, entries[len(entries)-1].Severity, "a second transaction cannot be opened")
+	assert.Equal(t, []string{"pg"}, tui.openTransactions())
+
+	tui.Pages = tview.NewPages()
+	tui.sourceSelected(0, "my", "", 0)
+	assert.True(t, tui.Pages.HasPage(confirmPage), "leaving an open transaction needs confirmation")
+	tui.hideOverlay(confirmPage)
+
+	tui.showError(fmt.Errorf("%w: pq: division by zero", internal.ErrTransactionAborted))
+	assert.Equal(t, "Query [ Ctrl-Q ] · transaction aborted", tui.QueryEditor.GetTitle())
+	assert.Contains(t, tui.statusText(), "· transaction aborted ·")
+	entries = tui.messages.Entries()
+	assert.Contains(t, entries[len(entries)-1].Text, "roll it back with "+tui.keys.Label(KeyRollbackOp))
+
+	tui.commitTransaction()
+	assert.False(t, ds.open)
+	assert.Empty(t, tui.openTransactions())
+	assert.Equal(t, "Query [ Ctrl-Q ]", tui.QueryEditor.GetTitle())
+	assert.Contains(t, tui.statusText(), "· autocommit ·")
+
+	tui.rollbackTransaction()
+	entries = tui.messages.Entries()
+	assert.Equal(t, eventlog.Warning, entries[len(entries)-1].Severity, "there is nothing to roll back")
+}
@@ -3,151 +3,124 @@ package tui
 import (
 	"errors"
 	"fmt"
-	"time"
+	"os"
 
 	"github.com/kenanbek/dbui/internal"
+	"github.com/kenanbek/dbui/internal/completion"
+	"github.com/kenanbek/dbui/internal/eventlog"
+	"github.com/kenanbek/dbui/internal/export"
+	"github.com/kenanbek/dbui/internal/history"
+	"github.com/kenanbek/dbui/internal/layout"
+	"github.com/kenanbek/dbui/internal/metadata"
+	"github.com/kenanbek/dbui/internal/snippets"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
-type (
-	// KeyOp defines dbui specific hotkey operations.
-	KeyOp int16
-)
-
-const (
-	// KeySourcesOp is the operation corresponding to the activation of the Sources view.
-	KeySourcesOp KeyOp = iota
-	// KeySchemasOp is the operation corresponding to the activation of the Schemas view.
-	KeySchemasOp
-	// KeyTablesOp is the operation corresponding to the activation of the Tables view.
-	KeyTablesOp
-	// KeyPreviewOp is the operation corresponding to the activation of the Preview view.
-	KeyPreviewOp
-	// KeyQueryOp is the operation corresponding to the activation of the Query view.
-	KeyQueryOp
-)
-
-var (
-	// KeyMapping maps keyboard operations to their hotkeys. In the future, this part can be customized by the user configuration.
-	KeyMapping = map[KeyOp]tcell.Key{
-		KeySourcesOp: tcell.KeyCtrlA,
-		KeySchemasOp: tcell.KeyCtrlS,
-		KeyTablesOp:  tcell.KeyCtrlD,
-		KeyPreviewOp: tcell.KeyCtrlE,
-		KeyQueryOp:   tcell.KeyCtrlQ,
-	}
-
-	// TitleSourcesView is the title for Sources view.
-	TitleSourcesView = fmt.Sprintf("Sources [ %s ]", tcell.KeyNames[KeyMapping[KeySourcesOp]])
-	// TitleSchemasView is the title for Schemas view.
-	TitleSchemasView = fmt.Sprintf("Schemas [ %s ]", tcell.KeyNames[KeyMapping[KeySchemasOp]])
-	// TitleTablesView is the title for Tables view.
-	TitleTablesView = fmt.Sprintf("Tables [ %s ]", tcell.KeyNames[KeyMapping[KeyTablesOp]])
-	// TitlePreviewView is the title for Preview view.
-	TitlePreviewView = fmt.Sprintf("Preview [ %s ]", tcell.KeyNames[KeyMapping[KeyPreviewOp]])
-	// TitleQueryView is the title for Query view.
-	TitleQueryView = fmt.Sprintf("Query [ %s ]", tcell.KeyNames[KeyMapping[KeyQueryOp]])
-	// TitleFooterView is the title for Footer view.
-	TitleFooterView = "Navigate [ Tab / Shift-Tab ] · Focus [ Ctrl-F ] · Exit [ Ctrl-C ] \n Tables specific: Describe [ e ] · Preview [ p ]"
-)
-
 // TUI implement terminal user interface features.
 // It also provides easy-to-use, easy-to-access abstraction over underlying tview components.
 type TUI struct {
 	// Internal structures.
-	ac internal.AppConfig
-	dc internal.DataController
+	ac    internal.AppConfig
+	dc    internal.DataController
+	keys  *KeyMap
+	theme *Theme
 
 	// App level states.
 	focusMode bool
+	// metadata caches the schemas, tables and columns of every data source used for autocompletion.
+	metadata map[internal.DataSource]*metadata.Cache
+	history  *history.History
+	recall   historyRecall
+	// layouts remembers how the columns of table previews are shown.
+	layouts *layout.Store
+	// refreshOverlay updates the open overlay listing metadata with newly loaded entries.
+	refreshOverlay func()
+	// overlayReturn is the view focused before the first overlay was shown.
+	overlayReturn tview.Primitive
+	// library holds the saved queries listed in the Snippets view.
+	library []snippets.Snippet
+	// paramValues remembers the values last entered for snippet parameters by name.
+	paramValues map[string]string
+	// tabs are the result tabs of the preview, activeTab the index of the shown one.
+	tabs      []*resultTab
+	activeTab int
+	// copyFormat is the format rows are copied to the clipboard in.
+	copyFormat export.Format
+	// limit is the number of rows per preview page set on the command line, or 0 for the configured one.
+	limit int
+	// screen is the terminal the application runs on, also used to reach its clipboard.
+	screen tcell.Screen
+	// messages logs every message shown in the footer.
+	messages *eventlog.Log
+	// flash is the info or warning shown in the footer until it expires, nil when none is.
+	flash *eventlog.Entry
+	// messageViewer shows the message log while it is open, nil otherwise.
+	messageViewer *Viewer
+	// status describes the connection to the current data source in the status bar.
+	status status
+	// transactions holds the data sources with a transaction open, by alias, and aborted the aliases of the ones
+	// whose transaction a failing statement aborted.
+	transactions map[string]internal.DataSource
+	aborted      map[string]bool
 
 	// View components.
 	App          *tview.Application
+	Pages        *tview.Pages
 	Grid         *tview.Grid
+	StatusBar    *tview.TextView
 	Sources      *tview.List
 	Schemas      *tview.List
 	Tables       *tview.List
+	Snippets     *tview.List
+	TabBar       *TabBar
 	PreviewTable *tview.Table
-	QueryInput   *tview.InputField
+	QueryEditor  *Editor
 	FooterText   *tview.TextView
 }
 
-func (tui *TUI) resetMessage() {
-	tui.queueUpdateDraw(func() {
-		tui.FooterText.SetText(TitleFooterView).SetTextColor(tcell.ColorGray)
-	})
+// title renders a view title together with the keys focusing the view, e.g. "Sources [ Ctrl-A ]".
+func (tui *TUI) title(name string, op KeyOp) string {
+	return fmt.Sprintf("%s [ %s ]", name, tui.keys.Label(op))
+}
+
+// footer renders the help line shown in the footer out of the effective key bindings.
+func (tui *TUI) footer() string {
+	return fmt.Sprintf("Navigate [ %s / %s ] · Focus [ %s ] · Reload [ %s ] · Commands [ %s ] · Help [ %s ] · Messages [ %s ] · Exit [ %s ] \n Tables specific: Describe [ %s ] · Preview [ %s ] · Query specific: Run [ %s ] · Run all [ %s ]",
+		tui.keys.Label(KeyNextOp), tui.keys.Label(KeyPrevOp), tui.keys.Label(KeyFocusModeOp), tui.keys.Label(KeyReloadOp),
+		tui.keys.Label(KeyCommandOp), tui.keys.Label(KeyHelpOp), tui.keys.Label(KeyMessagesOp), tui.keys.Label(KeyQuitOp), tui.keys.Label(KeyDescribeTableOp), tui.keys.Label(KeyPreviewTableOp),
+		tui.keys.Label(KeyExecuteOp), tui.keys.Label(KeyExecuteAllOp))
 }
 
+// showMessage reports the outcome of an action.
 func (tui *TUI) showMessage(msg string) {
-	tui.queueUpdateDraw(func() {
-		tui.FooterText.SetText(msg).SetTextColor(tcell.ColorGreen)
-	})
-	go time.AfterFunc(3*time.Second, tui.resetMessage)
+	tui.notify(eventlog.Info, msg)
 }
 
+// showWarning reports an action which could not be carried out as asked.
 func (tui *TUI) showWarning(msg string) {
-	tui.queueUpdateDraw(func() {
-		tui.FooterText.SetText(msg).SetTextColor(tcell.ColorYellow)
-	})
-	go time.AfterFunc(3*time.Second, tui.resetMessage)
+	tui.notify(eventlog.Warning, msg)
 }
 
+// showError reports a failure. It stays in the footer until the message log is read. A failure which aborted the
+// transaction of the current data source flags it until it is rolled back.
 func (tui *TUI) showError(err error) {
-	tui.queueUpdateDraw(func() {
-		tui.FooterText.SetText(err.Error()).SetTextColor(tcell.ColorRed)
-	})
-	go time.AfterFunc(3*time.Second, tui.resetMessage)
-}
-
-func (tui *TUI) showData(label string, data [][]*string) {
-	tui.queueUpdateDraw(func() {
-		tui.PreviewTable.Clear()
-
-		if len(data) == 0 {
-			return
-		}
-
-		for i, row := range data {
-			for j, col := range row {
-				var cellValue string
-				var cellColor = tcell.ColorWhite
-				var notSelectable = false
-
-				if col != nil {
-					cellValue = *col
-				}
-				if i == 0 {
-					notSelectable = true
-					cellColor = tcell.ColorYellow
-				}
-
-				tui.PreviewTable.SetCell(
-					i, j,
-					&tview.TableCell{
-						Text:          cellValue,
-						Color:         cellColor,
-						NotSelectable: notSelectable,
-					},
-				)
-			}
-		}
-		tui.PreviewTable.SetTitle(fmt.Sprintf("%s: %s", TitlePreviewView, label))
-		tui.PreviewTable.SetFixed(1, 1)
-		tui.PreviewTable.SetSelectable(true, false)
-		tui.PreviewTable.ScrollToBeginning()
-	})
+	if errors.Is(err, internal.ErrTransactionAborted) {
+		tui.abortTransaction()
+		err = fmt.Errorf("%w; roll it back with %s", err, tui.keys.Label(KeyRollbackOp))
+	}
+	tui.notify(eventlog.Error, err.Error())
 }
 
 func (tui *TUI) toggleFocusMode() {
 	if tui.focusMode {
 		tui.queueUpdateDraw(func() {
-			tui.Grid.SetRows(0, 2).SetColumns(40, 0)
+			tui.Grid.SetRows(1, 0, 2).SetColumns(40, 0)
 		})
 	} else {
 		tui.queueUpdateDraw(func() {
-			tui.Grid.SetRows(0, 2).SetColumns(1, 0)
+			tui.Grid.SetRows(1, 0, 2).SetColumns(1, 0)
 		})
 	}
 	tui.focusMode = !tui.focusMode
@@ -188,13 +161,19 @@ func (tui *TUI) previewSelectedTable() {
 		return
 	}
 
-	data, err := tui.dc.Current().PreviewTable(schema, table)
+	tui.previewNamedTable(schema, table)
+}
+
+// previewNamedTable previews the table of the schema in the active tab.
+func (tui *TUI) previewNamedTable(schema, table string) {
+	result, err := tui.previewTable(schema, table)
 	if err != nil {
 		tui.showError(err)
 		return
 	}
 
-	tui.showData(fmt.Sprintf("preview %s", table), data)
+	result.label = fmt.Sprintf("preview %s", table)
+	tui.showData(result)
 	tui.showMessage(fmt.Sprintf("PreviewTable \"%s\" table executed successfully!", table))
 }
 
@@ -211,16 +190,47 @@ func (tui *TUI) describeSelectedTable() {
 		return
 	}
 
+	tui.describeTable(schema, table)
+}
+
+// describeTable shows the structure of the table of the schema in the active tab.
+func (tui *TUI) describeTable(schema, table string) {
 	data, err := tui.dc.Current().DescribeTable(schema, table)
 	if err != nil {
 		tui.showError(err)
 		return
 	}
 
-	tui.showData(fmt.Sprintf("describe %s", table), data)
+	// The description is not a result of the table, so it carries no table name.
+	tui.showData(resultTab{label: fmt.Sprintf("describe %s", table), schema: schema, data: data})
 	tui.showMessage(fmt.Sprintf("Describe \"%s\" table executed successfully!", table))
 }
 
+// currentMetadata returns the metadata cache of the current data source.
+func (tui *TUI) currentMetadata() *metadata.Cache {
+	ds := tui.dc.Current()
+	cache, ok := tui.metadata[ds]
+	if !ok {
+		cache = metadata.New(ds, func() {
+			tui.queueUpdateDraw(func() {
+				tui.QueryEditor.RefreshCompletion()
+				if tui.refreshOverlay != nil {
+					tui.refreshOverlay()
+				}
+			})
+		})
+		tui.metadata[ds] = cache
+	}
+
+	return cache
+}
+
+// complete provides the completion candidates of the Query editor, resolving unqualified tables in the selected schema.
+func (tui *TUI) complete(text string, offset int) (int, []completion.Candidate) {
+	schema, _ := tui.getSelectedSchema()
+	return completion.Complete(text, offset, schema, tui.currentMetadata())
+}
+
 func (tui *TUI) setFocus(p tview.Primitive) {
 	tui.queueUpdateDraw(func() {
 		tui.App.SetFocus(p)
@@ -241,53 +251,117 @@ func (tui *TUI) queueUpdateDraw(f func()) {
 }
 
 // NewTUI configures and returns an instance of terminal user interface.
-func NewTUI(appConfig internal.AppConfig, dataController internal.DataController) *TUI {
-	t := TUI{ac: appConfig, dc: dataController}
+// It fails when the key bindings of the configuration are invalid or conflicting.
+func NewTUI(appConfig internal.AppConfig, dataController internal.DataController) (*TUI, error) {
+	keys, err := NewKeyMap(appConfig.Keys())
+	if err != nil {
+		return nil, err
+	}
+
+	theme, err := NewTheme(appConfig.Theme(), appConfig.Themes(), os.Getenv("NO_COLOR") != "")
+	if err != nil {
+		return nil, err
+	}
+	// Primitives pick up the tview defaults on creation, so they are set first.
+	theme.applyGlobalStyles()
+
+	t := TUI{
+		ac:          appConfig,
+		dc:          dataController,
+		keys:        keys,
+		theme:       theme,
+		metadata:    map[internal.DataSource]*metadata.Cache{},
+		messages:    eventlog.New(eventlog.DefaultLimit),
+		recall:      historyRecall{index: -1},
+		paramValues: map[string]string{},
+		tabs:        []*resultTab{{}},
+	}
 	t.App = tview.NewApplication()
 
+	// A broken history file must not prevent working with the databases, so the history falls back to memory.
+	historyFile, err := history.DefaultFile()
+	if err == nil {
+		t.history, err = history.Open(historyFile, history.DefaultLimit)
+	}
+	if err != nil {
+		t.history, _ = history.Open("", history.DefaultLimit)
+		t.showWarning(fmt.Sprintf("Query history is not saved: %v", err))
+	}
+	layoutsFile, err := layout.DefaultFile()
+	if err == nil {
+		t.layouts, err = layout.Open(layoutsFile)
+	}
+	if err != nil {
+		t.layouts, _ = layout.Open("")
+		t.showWarning(fmt.Sprintf("Column layouts are not saved: %v", err))
+	}
+
 	// Setup view elements.
-	t.Sources = tview.NewList().ShowSecondaryText(true).SetSecondaryTextColor(tcell.ColorDimGray)
+	t.Sources = tview.NewList().ShowSecondaryText(true).SetSecondaryTextStyle(theme.Secondary)
 	t.Schemas = tview.NewList().ShowSecondaryText(false)
 	t.Tables = tview.NewList().ShowSecondaryText(false)
-	t.PreviewTable = tview.NewTable().SetSelectedStyle(tcell.Style{}.Foreground(tcell.ColorBlack).Background(tcell.ColorWhite))
-	t.QueryInput = tview.NewInputField()
-	t.FooterText = tview.NewTextView().SetTextAlign(tview.AlignCenter).SetText(TitleFooterView).SetTextColor(tcell.ColorGray)
+	t.Snippets = tview.NewList().ShowSecondaryText(true).SetSecondaryTextStyle(theme.Secondary)
+	t.TabBar = NewTabBar(theme).SetSelectedFunc(t.switchTab)
+	t.PreviewTable = tview.NewTable().SetSelectedStyle(theme.Selected)
+	indent, tabs := DefaultIndent, false
+	if ec := appConfig.Editor(); ec != nil {
+		indent, tabs = ec.Indent(), ec.Tabs()
+	}
+	t.QueryEditor = NewEditor(theme, indent, tabs)
+	t.QueryEditor.SetCompleteFunc(t.complete)
+	t.FooterText = tview.NewTextView().SetTextAlign(tview.AlignCenter).SetText(t.footer()).SetTextStyle(theme.Footer)
+	t.StatusBar = tview.NewTextView().SetWrap(false).SetTextStyle(theme.Footer)
+	hints := fmt.Sprintf("Help [ %s ] · Switch context [ %s ] ", t.keys.Label(KeyHelpOp), t.keys.Label(KeySourcesOp))
+	header := tview.NewFlex().
+		AddItem(t.StatusBar, 0, 1, false).
+		AddItem(tview.NewTextView().SetTextAlign(tview.AlignRight).SetText(hints).SetTextStyle(theme.Footer), tview.TaggedStringWidth(hints), 0, false)
+	for _, list := range []*tview.List{t.Sources, t.Schemas, t.Tables, t.Snippets} {
+		list.SetMainTextStyle(theme.Text).SetSelectedStyle(theme.Selected)
+	}
 
 	// Configure appearance.
-	t.Sources.SetTitle(TitleSourcesView).SetBorder(true)
-	t.Schemas.SetTitle(TitleSchemasView).SetBorder(true)
-	t.Tables.SetTitle(TitleTablesView).SetBorder(true)
-	t.PreviewTable.SetTitle(TitlePreviewView).SetBorder(true)
-	t.QueryInput.SetTitle(TitleQueryView).SetBorder(true)
+	t.Sources.SetTitle(t.title("Sources", KeySourcesOp)).SetBorder(true)
+	t.Schemas.SetTitle(t.title("Schemas", KeySchemasOp)).SetBorder(true)
+	t.Tables.SetTitle(t.title("Tables", KeyTablesOp)).SetBorder(true)
+	t.Snippets.SetTitle(t.title("Snippets", KeySnippetsOp)).SetBorder(true)
+	t.PreviewTable.SetTitle(t.title("Preview", KeyPreviewOp)).SetBorder(true)
+	t.QueryEditor.SetTitle(t.title("Query", KeyQueryOp)).SetBorder(true)
 
 	// Configure input handlers.
 	t.Tables.SetSelectedFunc(t.tableSelected)
+	t.Snippets.SetSelectedFunc(t.snippetSelected)
 	t.Schemas.SetSelectedFunc(t.schemaSelected)
 	t.Sources.SetSelectedFunc(t.sourceSelected)
-	t.QueryInput.SetDoneFunc(t.queryExecuted)
+	t.Schemas.SetChangedFunc(func(int, string, string, rune) { t.renderStatus() })
 
 	// Setup grid layout.
-	navigate := tview.NewGrid().SetRows(0, 0, 0).
+	navigate := tview.NewGrid().SetRows(0, 0, 0, 0).
 		AddItem(t.Sources, 0, 0, 1, 1, 0, 0, true).
 		AddItem(t.Schemas, 1, 0, 1, 1, 0, 0, false).
-		AddItem(t.Tables, 2, 0, 1, 1, 0, 0, false)
-	previewAndQuery := tview.NewGrid().SetRows(0, 3).
-		AddItem(t.PreviewTable, 0, 0, 1, 1, 0, 0, false).
-		AddItem(t.QueryInput, 1, 0, 1, 1, 0, 0, false)
+		AddItem(t.Tables, 2, 0, 1, 1, 0, 0, false).
+		AddItem(t.Snippets, 3, 0, 1, 1, 0, 0, false)
+	previewAndQuery := tview.NewGrid().SetRows(1, 0, 10).
+		AddItem(t.TabBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(t.PreviewTable, 1, 0, 1, 1, 0, 0, false).
+		AddItem(t.QueryEditor, 2, 0, 1, 1, 0, 0, false)
 	t.Grid = tview.NewGrid().
-		SetRows(0, 2).
+		SetRows(1, 0, 2).
 		SetColumns(40, 0).
 		SetBorders(false).
-		AddItem(navigate, 0, 0, 1, 1, 0, 0, true).
-		AddItem(previewAndQuery, 0, 1, 1, 1, 0, 0, false).
-		AddItem(t.FooterText, 1, 0, 1, 2, 0, 0, false)
+		AddItem(header, 0, 0, 1, 2, 0, 0, false).
+		AddItem(navigate, 1, 0, 1, 1, 0, 0, true).
+		AddItem(previewAndQuery, 1, 1, 1, 1, 0, 0, false).
+		AddItem(t.FooterText, 2, 0, 1, 2, 0, 0, false)
+	t.Pages = tview.NewPages().AddPage(mainPage, t.Grid, true, true)
 
 	// Focus-driven border highlight. An after-draw hook is never an option here:
 	// queueing a draw from it re-fires the hook and the loop spins at 100% CPU (#54).
-	for _, box := range []*tview.Box{t.Sources.Box, t.Schemas.Box, t.Tables.Box, t.PreviewTable.Box, t.QueryInput.Box} {
-		box.SetFocusFunc(func() { box.SetBorderColor(tcell.ColorGreen) })
-		box.SetBlurFunc(func() { box.SetBorderColor(tcell.ColorWhite) })
+	for _, box := range []*tview.Box{t.Sources.Box, t.Schemas.Box, t.Tables.Box, t.Snippets.Box, t.PreviewTable.Box, t.QueryEditor.Box} {
+		box.SetBorderStyle(theme.Border).SetTitleColor(theme.Title)
+		box.SetFocusFunc(func() { box.SetBorderStyle(theme.FocusBorder) })
+		box.SetBlurFunc(func() { box.SetBorderStyle(theme.Border) })
 	}
+	t.renderTab()
 	t.setupKeyboard()
 
 	// TODO: Use-case when config was updated. Reload data sources.
@@ -300,19 +374,30 @@ func NewTUI(appConfig internal.AppConfig, dataController internal.DataController
 	}
 	t.LoadData()
 
-	return &t
+	return &t, nil
 }
 
 // Start starts terminal user interface application.
 func (tui *TUI) Start() error {
-	return tui.App.SetRoot(tui.Grid, true).EnableMouse(true).Run()
+	if tui.screen == nil {
+		screen, err := tcell.NewScreen()
+		if err != nil {
+			return err
+		}
+		tui.screen = screen
+	}
+
+	return tui.App.SetScreen(tui.screen).SetRoot(tui.Pages, true).EnableMouse(true).EnablePaste(true).Run()
 }
 
 // LoadData prepares user interface components based on their data sources.
 func (tui *TUI) LoadData() {
 	tui.Tables.Clear()
-	tui.PreviewTable.Clear().SetTitle(TitlePreviewView)
 	tui.Schemas.Clear()
+	tui.currentMetadata().Invalidate()
+	tui.refreshStatus()
+	tui.renderTransaction()
+	tui.loadSnippets()
 
 	schemas, err := tui.dc.Current().ListSchemas()
 	if err != nil {
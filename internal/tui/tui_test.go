@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kenanbek/dbui/internal/eventlog"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTUI(t *testing.T) *TUI {
+	keys, err := NewKeyMap(nil)
+	require.NoError(t, err)
+	theme, err := NewTheme("", nil, true)
+	require.NoError(t, err)
+
+	tui := &TUI{keys: keys, theme: theme, messages: eventlog.New(eventlog.DefaultLimit), tabs: []*resultTab{{}}, TabBar: NewTabBar(theme), PreviewTable: tview.NewTable()}
+	tui.renderTab()
+
+	return tui
+}
+
+func testData(rows int) [][]*string {
+	data := [][]*string{{sptr("id")}}
+	for i := 0; i < rows; i++ {
+		data = append(data, []*string{sptr(fmt.Sprint(i))})
+	}
+	return data
+}
+
+func sptr(s string) *string {
+	return &s
+}
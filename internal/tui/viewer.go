@@ -0,0 +1,196 @@
+package tui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rivo/uniseg"
+)
+
+// hangLeading makes wrapped rows of a Viewer line start below the first non-blank character of the line.
+const hangLeading = -1
+
+// Viewer shows read-only styled text. Lines longer than the view are wrapped, and the text scrolls vertically.
+type Viewer struct {
+	*tview.Box
+
+	lines []viewerLine
+	// hang is the indentation of wrapped rows, or hangLeading.
+	hang int
+	// offset is the first visible row of the wrapped text.
+	offset int
+	height int
+}
+
+type viewerLine struct {
+	text   []rune
+	styles []tcell.Style
+}
+
+// viewerRow is a row of the wrapped text: the runes from up to to of a line, indented by indent cells.
+type viewerRow struct {
+	line, from, to, indent int
+}
+
+// NewViewer returns an empty viewer.
+func NewViewer() *Viewer {
+	return &Viewer{Box: tview.NewBox(), lines: []viewerLine{{}}}
+}
+
+// Clear removes the text and scrolls back to the top.
+func (v *Viewer) Clear() *Viewer {
+	v.lines, v.offset = []viewerLine{{}}, 0
+	return v
+}
+
+// ScrollToEnd scrolls to the last line of the text.
+func (v *Viewer) ScrollToEnd() *Viewer {
+	v.offset = math.MaxInt32
+	return v
+}
+
+// SetHang sets the indentation of the rows a long line wraps into, in cells, or hangLeading.
+func (v *Viewer) SetHang(hang int) *Viewer {
+	v.hang = hang
+	return v
+}
+
+// Write appends the text in the style. Newlines start new lines.
+func (v *Viewer) Write(text string, style tcell.Style) *Viewer {
+	text = strings.NewReplacer("\r\n", "\n", "\r", "", "\t", "    ").Replace(text)
+	for i, part := range strings.Split(text, "\n") {
+		if i > 0 {
+			v.lines = append(v.lines, viewerLine{})
+		}
+		line := &v.lines[len(v.lines)-1]
+		for _, r := range part {
+			line.text = append(line.text, r)
+			line.styles = append(line.styles, style)
+		}
+	}
+
+	return v
+}
+
+// rows wraps the lines to the width.
+func (v *Viewer) rows(width int) []viewerRow {
+	var rows []viewerRow
+	for i, line := range v.lines {
+		indent := v.hang
+		if indent == hangLeading {
+			indent = 0
+			for indent < len(line.text) && line.text[indent] == ' ' {
+				indent++
+			}
+		}
+		// A hang leaving no room for the text is dropped.
+		if indent > width/2 {
+			indent = 0
+		}
+
+		row := viewerRow{line: i}
+		used := 0
+		for j, r := range line.text {
+			w := max(1, uniseg.StringWidth(string(r)))
+			if used+w > width && j > row.from {
+				row.to = j
+				rows = append(rows, row)
+				row = viewerRow{line: i, from: j, indent: indent}
+				used = indent
+			}
+			used += w
+		}
+		row.to = len(line.text)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// Draw draws this primitive onto the screen.
+func (v *Viewer) Draw(screen tcell.Screen) {
+	v.DrawForSubclass(screen, v)
+	x, y, width, height := v.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	v.height = height
+
+	rows := v.rows(width)
+	v.offset = max(0, min(v.offset, len(rows)-height))
+	for i := 0; i < height && v.offset+i < len(rows); i++ {
+		row := rows[v.offset+i]
+		line := v.lines[row.line]
+		col := x + row.indent
+		for j := row.from; j < row.to; j++ {
+			w := max(1, uniseg.StringWidth(string(line.text[j])))
+			if col+w > x+width {
+				break
+			}
+			screen.SetContent(col, y+i, line.text[j], nil, line.styles[j])
+			col += w
+		}
+	}
+}
+
+// scroll moves the view by the number of rows. Moving past either end stops there on the next draw.
+func (v *Viewer) scroll(rows int) {
+	v.offset = max(0, v.offset+rows)
+}
+
+// InputHandler returns the handler for this primitive.
+func (v *Viewer) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, _ func(p tview.Primitive)) {
+		page := max(1, v.height-1)
+		switch event.Key() {
+		case tcell.KeyUp:
+			v.scroll(-1)
+		case tcell.KeyDown:
+			v.scroll(1)
+		case tcell.KeyPgUp:
+			v.scroll(-page)
+		case tcell.KeyPgDn:
+			v.scroll(page)
+		case tcell.KeyHome:
+			v.offset = 0
+		case tcell.KeyEnd:
+			v.offset = math.MaxInt32
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'k':
+				v.scroll(-1)
+			case 'j':
+				v.scroll(1)
+			case ' ':
+				v.scroll(page)
+			case 'g':
+				v.offset = 0
+			case 'G':
+				v.offset = math.MaxInt32
+			}
+		}
+	})
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (v *Viewer) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	return v.WrapMouseHandler(func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if !v.InRect(event.Position()) {
+			return false, nil
+		}
+
+		switch action {
+		case tview.MouseScrollUp:
+			v.scroll(-3)
+		case tview.MouseScrollDown:
+			v.scroll(3)
+		case tview.MouseLeftDown:
+			setFocus(v)
+		default:
+			return false, nil
+		}
+		return true, nil
+	})
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewer_Rows(t *testing.T) {
+	v := NewViewer().Write("abcdefgh\n  ijklmnop", tcell.StyleDefault)
+	assert.Equal(t, []viewerRow{{0, 0, 5, 0}, {0, 5, 8, 0}, {1, 0, 5, 0}, {1, 5, 10, 0}}, v.rows(5))
+
+	v.SetHang(hangLeading)
+	assert.Equal(t, []viewerRow{{0, 0, 5, 0}, {0, 5, 8, 0}, {1, 0, 5, 0}, {1, 5, 8, 2}, {1, 8, 10, 2}}, v.rows(5),
+		"wrapped rows line up with the indentation of their line")
+
+	v.SetHang(4)
+	assert.Equal(t, []viewerRow{{0, 0, 8, 0}, {1, 0, 10, 0}}, v.Clear().Write("abcdefgh\n  ijklmnop", tcell.StyleDefault).rows(10))
+	assert.Equal(t, []viewerRow{{0, 0, 3, 0}, {0, 3, 6, 0}, {0, 6, 8, 0}}, v.Clear().Write("abcdefgh", tcell.StyleDefault).rows(3),
+		"a hang wider than half the view is dropped")
+}
+
+func TestTUI_WriteRecord(t *testing.T) {
+	tui := newTestTUI(t)
+	tui.currentTab().data = [][]*string{{sptr("id"), sptr("comment")}, {sptr("1"), sptr("a\nb")}, {sptr("2"), nil}}
+
+	v := NewViewer()
+	tui.writeRecord(v, 1)
+	var lines []string
+	for _, l := range v.lines {
+		lines = append(lines, string(l.text))
+	}
+	assert.Equal(t, []string{"id      │ 1", "comment │ a", "          b"}, lines)
+	assert.Equal(t, 10, v.hang)
+
+	tui.writeRecord(v.Clear(), 2)
+	assert.Equal(t, "comment │ NULL", string(v.lines[1].text))
+}
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/kenanbek/dbui/internal/clipboard"
+	"github.com/kenanbek/dbui/internal/export"
+	"github.com/kenanbek/dbui/internal/sqlgen"
+)
+
+// typeOf returns the type of the data source with the alias.
+func (tui *TUI) typeOf(alias string) string {
+	for _, aliasType := range tui.dc.List() {
+		if aliasType[0] == alias {
+			return aliasType[1]
+		}
+	}
+
+	return ""
+}
+
+// resultColumns returns the column names of the result shown in the active tab.
+func (tui *TUI) resultColumns() []string {
+	tab := tui.currentTab()
+	if len(tab.data) == 0 {
+		return nil
+	}
+
+	columns := make([]string, len(tab.data[0]))
+	for i, c := range tab.data[0] {
+		if c != nil {
+			columns[i] = *c
+		}
+	}
+	return columns
+}
+
+// exportOptions returns the options formatting rows of the active tab: INSERT statements target its table,
+// named as in the preview and quoted for the type of the data source it came from.
+func (tui *TUI) exportOptions(header bool) export.Options {
+	tab := tui.currentTab()
+	dialect := sqlgen.DialectFor(tui.typeOf(tab.alias))
+	opts := export.Options{Header: header, Dialect: dialect}
+	if tab.table != "" {
+		opts.Table = dialect.QuoteIdent(tab.table)
+	}
+
+	return opts
+}
+
+// exportRows writes every row of the active tab to the file in the format, with the column names for TSV and CSV.
+func (tui *TUI) exportRows(format export.Format, file string) error {
+	tab := tui.currentTab()
+	if len(tab.data) == 0 {
+		return errors.New("no rows to export")
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	err = export.Write(f, format, tui.resultColumns(), tab.data[1:], tui.exportOptions(true))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	tui.showMessage(fmt.Sprintf("Exported %s as %s to %s", countRows(len(tab.data)-1), format, file))
+	return nil
+}
+
+// copyText puts the text on the clipboard and reports what was copied.
+func (tui *TUI) copyText(text, what string) {
+	via, err := clipboard.Copy(tui.screen, text)
+	if err != nil {
+		tui.showError(fmt.Errorf("copying with %s: %w", via, err))
+		return
+	}
+	tui.showMessage(fmt.Sprintf("Copied %s", what))
+}
+
+// copyCell copies the raw value of the selected cell.
+func (tui *TUI) copyCell() {
+	row, column, ok := tui.selectedCell()
+	if !ok {
+		tui.showWarning("no cell to copy")
+		return
+	}
+
+	value := tui.currentTab().data[row][column]
+	if value == nil {
+		tui.copyText("NULL", "NULL")
+		return
+	}
+	tui.copyText(*value, fmt.Sprintf("%d characters", len([]rune(*value))))
+}
+
+// copyRows copies the selected row, or all rows with the column names, in the copy format.
+func (tui *TUI) copyRows(all bool) {
+	tab := tui.currentTab()
+	rows := tab.data[min(1, len(tab.data)):]
+	if !all {
+		row, _ := tui.PreviewTable.GetSelection()
+		if row < 1 || row >= len(tab.data) {
+			tui.showWarning("no row to copy")
+			return
+		}
+		rows = tab.data[row : row+1]
+	}
+	if len(rows) == 0 {
+		tui.showWarning("no rows to copy")
+		return
+	}
+
+	text, err := export.String(tui.copyFormat, tui.resultColumns(), rows, tui.exportOptions(all))
+	if err != nil {
+		tui.showError(err)
+		return
+	}
+
+	tui.copyText(text, fmt.Sprintf("%s as %s", countRows(len(rows)), tui.copyFormat))
+}
+
+// cycleCopyFormat switches to the next format rows are copied in.
+func (tui *TUI) cycleCopyFormat() {
+	tui.copyFormat = export.Formats[(int(tui.copyFormat)+1)%len(export.Formats)]
+	tui.showMessage(fmt.Sprintf("Rows are copied as %s", tui.copyFormat))
+}
@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kenanbek/dbui/internal/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTUI_ExportOptions(t *testing.T) {
+	tui := newTestTUI(t)
+	tui.dc = fakeController{alias: "pg", sources: [][]string{{"my", "mysql"}, {"pg", "postgresql"}}}
+	data := [][]*string{{sptr("id"), sptr("name")}, {sptr("1"), nil}}
+
+	*tui.currentTab() = resultTab{alias: "my", schema: "shop", table: "orders", data: data}
+	assert.Equal(t, []string{"id", "name"}, tui.resultColumns())
+	text, err := export.String(export.Insert, tui.resultColumns(), data[1:], tui.exportOptions(false))
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `orders` (`id`, `name`) VALUES ('1', NULL);\n", text,
+		"statements target the table of the result, quoted for the source it came from")
+
+	*tui.currentTab() = resultTab{alias: "pg", sql: "select 1", data: data}
+	opts := tui.exportOptions(true)
+	assert.Empty(t, opts.Table, "query results have no table")
+	assert.Equal(t, "postgresql", opts.Dialect.Type)
+	assert.True(t, opts.Header)
+}
@@ -5,7 +5,6 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 	"runtime/debug"
 
 	"github.com/kenanbek/dbui/internal/config"
@@ -23,6 +22,10 @@ func main() {
 }
 
 func run() int {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		return runConfig(os.Args[2:])
+	}
+
 	var (
 		fConfFile string
 		fDemo     bool
@@ -79,7 +82,11 @@ func run() int {
 		return 1
 	}
 
-	t := tui.NewTUI(appConfig, ctrl)
+	t, err := tui.NewTUI(appConfig, ctrl)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
 	if err := t.Start(); err != nil {
 		fmt.Fprintf(os.Stderr, "failed to start: %v\n", err)
 		return 1
@@ -88,6 +95,46 @@ func run() int {
 	return 0
 }
 
+// runConfig implements the `dbui config <command>` subcommands.
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "validate" {
+		fmt.Fprintln(os.Stderr, "usage: dbui config validate [-f file]")
+		return 2
+	}
+
+	var fConfFile string
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	fs.StringVar(&fConfFile, "f", "", "configuration file to validate instead of the discovered ones")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	files := []string{fConfFile}
+	if fConfFile == "" {
+		var err error
+		if files, err = config.Discover(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	diagnostics := config.Validate(config.Rules{
+		Types:            controller.SupportedTypes,
+		DSN:              controller.ValidateDSN,
+		KeyBinding:       tui.ValidateKeyBinding,
+		Theme:            tui.ValidateTheme,
+		StatementClasses: tui.StatementClasses(),
+	}, files...)
+	for _, d := range diagnostics {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	if len(diagnostics) > 0 {
+		return 1
+	}
+
+	return 0
+}
+
 func versionString() string {
 	if version != "" {
 		return fmt.Sprintf("dbui %s (%s)", version, date)
@@ -99,24 +146,18 @@ func versionString() string {
 }
 
 func readConfig(customConfigFile string) (*config.AppConfig, error) {
-	confPath := customConfigFile
-	if confPath != "" {
-		if _, err := os.Stat(confPath); err != nil {
-			return nil, fmt.Errorf("configuration file %q does not exist", confPath)
-		}
-	} else {
-		confPath = "dbui.yml"
-		if _, err := os.Stat(confPath); err != nil {
-			userDir, homeErr := os.UserHomeDir()
-			if homeErr != nil {
-				return nil, homeErr
-			}
-			confPath = filepath.Join(userDir, "dbui.yml")
-			if _, err := os.Stat(confPath); err != nil {
-				return nil, fmt.Errorf("no dbui.yml in the current or home directory; create one or use -dsn and -type")
-			}
+	if customConfigFile != "" {
+		if _, err := os.Stat(customConfigFile); err != nil {
+			return nil, fmt.Errorf("configuration file %q does not exist", customConfigFile)
 		}
+
+		return config.Load(customConfigFile)
+	}
+
+	files, err := config.Discover()
+	if err != nil {
+		return nil, err
 	}
 
-	return config.New(confPath)
+	return config.Load(files...)
 }